@@ -0,0 +1,74 @@
+package engine
+
+type ActionType uint
+
+const (
+	ActionTypeUndefined = ActionType(iota)
+	ActionTypeEat
+	ActionTypeHide
+
+	// ActionTypeLend gives the food to eat to the destination, which
+	// then owes it to the citizen (see Config.EnableLending).
+	ActionTypeLend
+
+	// ActionTypeRepay gives the food to eat to the destination to repay
+	// the debts owed to it, the oldest first.
+	ActionTypeRepay
+
+	// ActionTypePunish makes the citizen spend Amount of its hidden food
+	// and then of its energy (not of the food portion) to make
+	// the destination lose Amount*PunishmentEfficiency of its hidden
+	// food and then of its energy (see Config.EnablePunishment).
+	ActionTypePunish
+
+	// ActionTypeGuard destroys the food to make the hidden food of
+	// the destination (the citizen itself or its child) harder to steal
+	// until the next theft phase (see Config.EnableTheft).
+	ActionTypeGuard
+)
+
+func (actionType ActionType) String() string {
+	switch actionType {
+	case ActionTypeUndefined:
+		return "undefined"
+	case ActionTypeEat:
+		return "eat"
+	case ActionTypeHide:
+		return "hide"
+	case ActionTypeLend:
+		return "lend"
+	case ActionTypeRepay:
+		return "repay"
+	case ActionTypePunish:
+		return "punish"
+	case ActionTypeGuard:
+		return "guard"
+	}
+	return "unknown"
+}
+
+type Food struct {
+	AlreadyHidden bool
+	Amount        uint
+
+	// Taxed is set if the portion already went through the tax
+	// collection (see Config.EnableInstitution), so that the food
+	// rediscovered from it is not taxed again.
+	Taxed bool
+}
+
+type Action struct {
+	ActionType
+	Amount      uint
+	Destination PersonID
+	Comment     string
+}
+
+// Strategy decides what to do with a food portion found by a citizen.
+//
+// The returned actions except ActionTypePunish should use exactly
+// the whole food.Amount, and their destinations should be observed
+// (see Observation).
+type Strategy interface {
+	HandleFood(observation *Observation, food Food) []Action
+}
@@ -0,0 +1,323 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// Config contains all the knobs of the world a Playground simulates.
+type Config struct {
+	// RequiredEnergy is the amount of energy a person burns every week.
+	RequiredEnergy uint `yaml:"required_energy"`
+
+	// AmountOfPortions is the amount of food portions found every week.
+	AmountOfPortions uint `yaml:"amount_of_portions"`
+
+	// PortionEnergy is the energy of a single found food portion.
+	PortionEnergy uint `yaml:"portion_energy"`
+
+	// ExtraFoodEfficiency is the efficiency of converting food eaten
+	// above RequiredEnergy into stored energy.
+	ExtraFoodEfficiency float64 `yaml:"extra_food_efficiency"`
+
+	// PersonGraduationInWeeks is the age when a child becomes a citizen.
+	PersonGraduationInWeeks uint `yaml:"person_graduation_in_weeks"`
+
+	// PersonExpirationInWeeks is the age when a citizen dies of aging.
+	PersonExpirationInWeeks uint `yaml:"person_expiration_in_weeks"`
+
+	// StartBabyEnergy is the energy a citizen needs to have to make a baby.
+	StartBabyEnergy uint `yaml:"start_baby_energy"`
+
+	// CreateBabyEnergy is the energy a citizen spends to make a baby
+	// (half of it is received by the baby).
+	CreateBabyEnergy uint `yaml:"create_baby_energy"`
+
+	EnableChildren bool `yaml:"enable_children"`
+	EnableAging    bool `yaml:"enable_aging"`
+
+	// ChangeStrategyExponent defines the distribution of
+	// Citizen.ChangeStrategyProbability: it is a product of this
+	// amount of uniformly random numbers in [0, 1). Zero disables
+	// strategy changing completely.
+	ChangeStrategyExponent uint `yaml:"change_strategy_exponent"`
+
+	// HiddenFoodRediscoveryProbability is the probability that
+	// the food which is being hidden is found by somebody else
+	// (and is distributed again).
+	HiddenFoodRediscoveryProbability float64 `yaml:"hidden_food_rediscovery_probability"`
+
+	// ShuffleCitizens makes the citizens handle the found food in
+	// a random order every round of the food distribution, instead of
+	// the order they were added in.
+	ShuffleCitizens bool `yaml:"shuffle_citizens"`
+
+	// AllowSuicidalStrategies disables the check that a strategy
+	// does not let its own citizen starve while the food portion
+	// was enough to survive.
+	AllowSuicidalStrategies bool `yaml:"allow_suicidal_strategies"`
+
+	// EnablePrivateMemory makes every citizen remember who personally
+	// helped or refused it (see Citizen.Relationship), in addition to
+	// the public reputation.
+	EnablePrivateMemory bool `yaml:"enable_private_memory"`
+
+	// EnableGossip makes the observations of greed and help spread
+	// as rumors along social links (see Citizen.Reputation), in addition
+	// to the public reputation. Every week each observed citizen is
+	// reported by a single witness: the saved one or a random hungry one.
+	EnableGossip bool `yaml:"enable_gossip"`
+
+	// GossipLinks is the amount of random citizens each citizen retells
+	// rumors to (a graduate is additionally linked with its parent).
+	GossipLinks uint `yaml:"gossip_links"`
+
+	// GossipDelayInWeeks is the delay between hearing a rumor and
+	// retelling it.
+	GossipDelayInWeeks uint `yaml:"gossip_delay_in_weeks"`
+
+	// GossipErrorRate is the probability that a retold rumor is heard
+	// inverted (greed as help and vice versa).
+	GossipErrorRate float64 `yaml:"gossip_error_rate"`
+
+	// GossipMaxHops is the maximal amount of retellings of a rumor.
+	GossipMaxHops uint `yaml:"gossip_max_hops"`
+
+	// EnableLending allows ActionTypeLend and ActionTypeRepay. A debt
+	// which is not repaid within LendingTermInWeeks is defaulted:
+	// it is written off and the debtor is flagged
+	// (see Citizen.SpottedAsDefaulter).
+	EnableLending bool `yaml:"enable_lending"`
+
+	// LendingInterestRate is the part of the lent amount to be repaid
+	// in addition to it.
+	LendingInterestRate float64 `yaml:"lending_interest_rate"`
+
+	LendingTermInWeeks uint `yaml:"lending_term_in_weeks"`
+
+	// EnablePunishment allows ActionTypePunish.
+	EnablePunishment bool `yaml:"enable_punishment"`
+
+	// PunishmentEfficiency is the amount of energy the punished loses
+	// per unit of food spent by the punisher.
+	PunishmentEfficiency float64 `yaml:"punishment_efficiency"`
+
+	// EnableTheft lets strategies implementing Thief steal hidden food
+	// (a single random stash per successful attempt) and allows
+	// ActionTypeGuard.
+	EnableTheft bool `yaml:"enable_theft"`
+
+	TheftSuccessProbability float64 `yaml:"theft_success_probability"`
+
+	// TheftDetectionProbability is the probability that a theft attempt
+	// (successful or not) is noticed; the thief is then spotted as
+	// greedy and as a thief.
+	TheftDetectionProbability float64 `yaml:"theft_detection_probability"`
+
+	TheftAttemptsPerWeek uint `yaml:"theft_attempts_per_week"`
+
+	// GuardHalvingAmount is the amount of food spent on guarding which
+	// halves the probability of a successful theft; zero makes guarding
+	// useless.
+	GuardHalvingAmount uint `yaml:"guard_halving_amount"`
+
+	// EnableInstitution makes a people's budget (see Institution)
+	// collect TaxRate of every found food portion before the finder's
+	// strategy handles it, and redistribute it every week according
+	// to RedistributionPolicy.
+	EnableInstitution    bool                 `yaml:"enable_institution"`
+	TaxRate              float64              `yaml:"tax_rate"`
+	RedistributionPolicy RedistributionPolicy `yaml:"redistribution_policy"`
+
+	// AuditProbability is the probability to catch a tax evasion
+	// (see TaxEvader); the caught evader pays the tax and AuditFine
+	// times the tax in addition.
+	AuditProbability float64 `yaml:"audit_probability"`
+	AuditFine        float64 `yaml:"audit_fine"`
+
+	// OrphanPolicy defines what happens to the children of a dead
+	// citizen: "none" (they die with it), "relatives", "altruists"
+	// or "school".
+	OrphanPolicy OrphanPolicy `yaml:"orphan_policy"`
+
+	// SchoolPortions is the amount of the found food portions spent
+	// on the school children every week (see OrphanPolicySchool).
+	SchoolPortions uint `yaml:"school_portions"`
+
+	// InheritancePolicy defines who receives the energy and the hidden
+	// food of a dead citizen: "lost" (nobody), "children", "family"
+	// or "community" (the Institution).
+	InheritancePolicy InheritancePolicy `yaml:"inheritance_policy"`
+
+	// EnableBequests lets strategies implementing Testator decide
+	// who inherits the estate.
+	EnableBequests bool `yaml:"enable_bequests"`
+
+	// EnableTwoParentReproduction makes babies be made by pairs of
+	// citizens who agree with each other (see MateChooser). Each parent
+	// needs half of StartBabyEnergy and spends half of CreateBabyEnergy;
+	// the child graduates with the strategy of a random parent.
+	EnableTwoParentReproduction bool `yaml:"enable_two_parent_reproduction"`
+
+	// TransmissionModel defines whose strategy a citizen adopts when
+	// it changes its strategy: "random", "payoff", "conformist",
+	// "prestige" or "vertical" (never).
+	TransmissionModel TransmissionModel `yaml:"transmission_model"`
+
+	// TransmissionSampleSize is the amount of random citizens compared
+	// by the payoff, conformist and prestige transmission models.
+	TransmissionSampleSize uint `yaml:"transmission_sample_size"`
+
+	// EnableChildhoodLearning makes children be born without a strategy:
+	// every LearningIntervalInWeeks a child takes a lesson from
+	// a channel chosen randomly according to the weights (the parent,
+	// a random citizen, or the most common strategy), and graduates with
+	// the strategy it got the most lessons of.
+	EnableChildhoodLearning        bool    `yaml:"enable_childhood_learning"`
+	LearningIntervalInWeeks        uint    `yaml:"learning_interval_in_weeks"`
+	LearningFromParentWeight       float64 `yaml:"learning_from_parent_weight"`
+	LearningFromPeersWeight        float64 `yaml:"learning_from_peers_weight"`
+	LearningFromInstitutionsWeight float64 `yaml:"learning_from_institutions_weight"`
+
+	// EnableGeneBorrowing makes a citizen following a Genotype borrow
+	// a single random gene of the strategy it changes to, instead of
+	// the whole strategy.
+	EnableGeneBorrowing bool `yaml:"enable_gene_borrowing"`
+
+	// GeneMutationRate is the probability of every gene of a Genotype
+	// to change to a random allele when the genotype is transmitted
+	// (on graduation or on a change of strategy).
+	GeneMutationRate float64 `yaml:"gene_mutation_rate"`
+}
+
+// DefaultConfig returns the configuration of the "longterm" experiment.
+func DefaultConfig() Config {
+	return Config{
+		RequiredEnergy:            1000,
+		AmountOfPortions:          500 / 3,
+		PortionEnergy:             1500,
+		ExtraFoodEfficiency:       1,
+		PersonGraduationInWeeks:   16 * 54,
+		PersonExpirationInWeeks:   80 * 54,
+		StartBabyEnergy:           50000,
+		CreateBabyEnergy:          40000,
+		EnableChildren:            true,
+		EnableAging:               true,
+		ChangeStrategyExponent:    4,
+		ShuffleCitizens:           true,
+		GossipLinks:               5,
+		GossipDelayInWeeks:        1,
+		GossipMaxHops:             3,
+		LendingInterestRate:       0.1,
+		LendingTermInWeeks:        54,
+		PunishmentEfficiency:      3,
+		TheftSuccessProbability:   0.5,
+		TheftDetectionProbability: 0.3,
+		TheftAttemptsPerWeek:      1,
+		GuardHalvingAmount:        100,
+		TaxRate:                   0.1,
+		RedistributionPolicy:      RedistributionPolicyHungry,
+		AuditProbability:          0.1,
+		AuditFine:                 2,
+		SchoolPortions:            5,
+		TransmissionSampleSize:    5,
+		LearningIntervalInWeeks:   54,
+		LearningFromParentWeight:  1,
+	}
+}
+
+// Validate returns an error if the configuration describes a world
+// which cannot be simulated.
+func (cfg *Config) Validate() error {
+	if cfg.RequiredEnergy == 0 {
+		return fmt.Errorf("required_energy should be positive")
+	}
+	if cfg.ExtraFoodEfficiency < 0 {
+		return fmt.Errorf("extra_food_efficiency should not be negative, but it is %f", cfg.ExtraFoodEfficiency)
+	}
+	if cfg.HiddenFoodRediscoveryProbability < 0 || cfg.HiddenFoodRediscoveryProbability > 1 {
+		return fmt.Errorf("hidden_food_rediscovery_probability should be within [0, 1], but it is %f",
+			cfg.HiddenFoodRediscoveryProbability)
+	}
+	if cfg.GossipErrorRate < 0 || cfg.GossipErrorRate > 1 {
+		return fmt.Errorf("gossip_error_rate should be within [0, 1], but it is %f", cfg.GossipErrorRate)
+	}
+	if cfg.EnableGossip && cfg.GossipLinks == 0 {
+		return fmt.Errorf("gossip_links should be positive when gossip is enabled")
+	}
+	if cfg.LendingInterestRate < 0 {
+		return fmt.Errorf("lending_interest_rate should not be negative, but it is %f", cfg.LendingInterestRate)
+	}
+	if cfg.EnableLending && cfg.LendingTermInWeeks == 0 {
+		return fmt.Errorf("lending_term_in_weeks should be positive when lending is enabled")
+	}
+	if cfg.PunishmentEfficiency < 0 {
+		return fmt.Errorf("punishment_efficiency should not be negative, but it is %f", cfg.PunishmentEfficiency)
+	}
+	if cfg.TheftSuccessProbability < 0 || cfg.TheftSuccessProbability > 1 {
+		return fmt.Errorf("theft_success_probability should be within [0, 1], but it is %f", cfg.TheftSuccessProbability)
+	}
+	if cfg.TheftDetectionProbability < 0 || cfg.TheftDetectionProbability > 1 {
+		return fmt.Errorf("theft_detection_probability should be within [0, 1], but it is %f", cfg.TheftDetectionProbability)
+	}
+	if cfg.TaxRate < 0 || cfg.TaxRate > 1 {
+		return fmt.Errorf("tax_rate should be within [0, 1], but it is %f", cfg.TaxRate)
+	}
+	if cfg.AuditProbability < 0 || cfg.AuditProbability > 1 {
+		return fmt.Errorf("audit_probability should be within [0, 1], but it is %f", cfg.AuditProbability)
+	}
+	if cfg.AuditFine < 0 {
+		return fmt.Errorf("audit_fine should not be negative, but it is %f", cfg.AuditFine)
+	}
+	if cfg.EnableInstitution && cfg.RedistributionPolicy == RedistributionPolicyUndefined {
+		return fmt.Errorf("redistribution_policy should be set when the institution is enabled")
+	}
+	if cfg.OrphanPolicy != OrphanPolicyNone && !cfg.EnableChildren {
+		return fmt.Errorf("orphan_policy requires enable_children")
+	}
+	if cfg.InheritancePolicy == InheritancePolicyCommunity && !cfg.EnableInstitution {
+		return fmt.Errorf("inheritance_policy 'community' requires enable_institution")
+	}
+	if cfg.EnableTwoParentReproduction && !cfg.EnableChildren {
+		return fmt.Errorf("enable_two_parent_reproduction requires enable_children")
+	}
+	if cfg.TransmissionSampleSize == 0 {
+		switch cfg.TransmissionModel {
+		case TransmissionModelPayoff, TransmissionModelConformist, TransmissionModelPrestige:
+			return fmt.Errorf("transmission_sample_size should be positive for the '%s' transmission model", cfg.TransmissionModel)
+		}
+	}
+	if cfg.EnableChildhoodLearning {
+		if !cfg.EnableChildren {
+			return fmt.Errorf("enable_childhood_learning requires enable_children")
+		}
+		if cfg.LearningIntervalInWeeks == 0 {
+			return fmt.Errorf("learning_interval_in_weeks should be positive")
+		}
+		if cfg.LearningFromParentWeight < 0 || cfg.LearningFromPeersWeight < 0 || cfg.LearningFromInstitutionsWeight < 0 {
+			return fmt.Errorf("learning weights should not be negative")
+		}
+		if cfg.LearningFromParentWeight+cfg.LearningFromPeersWeight+cfg.LearningFromInstitutionsWeight == 0 {
+			return fmt.Errorf("at least one learning weight should be positive")
+		}
+	}
+	if cfg.GeneMutationRate < 0 || cfg.GeneMutationRate > 1 {
+		return fmt.Errorf("gene_mutation_rate should be within [0, 1], but it is %f", cfg.GeneMutationRate)
+	}
+	if cfg.EnableAging && cfg.PersonExpirationInWeeks <= cfg.PersonGraduationInWeeks {
+		return fmt.Errorf("person_expiration_in_weeks (%d) should be greater than person_graduation_in_weeks (%d)",
+			cfg.PersonExpirationInWeeks, cfg.PersonGraduationInWeeks)
+	}
+	if cfg.EnableChildren {
+		if !cfg.EnableAging {
+			return fmt.Errorf("enable_children requires enable_aging, otherwise children never graduate")
+		}
+		if cfg.CreateBabyEnergy == 0 {
+			return fmt.Errorf("create_baby_energy should be positive when children are enabled")
+		}
+		if cfg.StartBabyEnergy < cfg.CreateBabyEnergy {
+			return fmt.Errorf("start_baby_energy (%d) should not be less than create_baby_energy (%d)",
+				cfg.StartBabyEnergy, cfg.CreateBabyEnergy)
+		}
+	}
+	return nil
+}
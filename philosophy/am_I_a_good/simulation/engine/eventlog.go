@@ -0,0 +1,26 @@
+package engine
+
+type eventCount struct {
+	WeekID uint
+	Count  uint
+}
+
+// eventLog counts events week by week.
+type eventLog []eventCount
+
+func (log *eventLog) add(weekID uint) {
+	if len(*log) > 0 && (*log)[len(*log)-1].WeekID == weekID {
+		(*log)[len(*log)-1].Count++
+		return
+	}
+	*log = append(*log, eventCount{WeekID: weekID, Count: 1})
+}
+
+// countSince returns the amount of events happened since weekID (inclusive).
+func (log eventLog) countSince(weekID uint) uint {
+	result := uint(0)
+	for idx := len(log) - 1; idx >= 0 && log[idx].WeekID >= weekID; idx-- {
+		result += log[idx].Count
+	}
+	return result
+}
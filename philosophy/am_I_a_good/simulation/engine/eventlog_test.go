@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"testing"
+)
+
+func TestEventLog(t *testing.T) {
+	var log eventLog
+	for _, weekID := range []uint{1, 1, 3, 4, 4, 4} {
+		log.add(weekID)
+	}
+	if len(log) != 3 {
+		t.Errorf("the events of the same week should be merged: %+v", log)
+	}
+	for _, tc := range []struct {
+		sinceWeekID uint
+		count       uint
+	}{
+		{sinceWeekID: 0, count: 6},
+		{sinceWeekID: 2, count: 4},
+		{sinceWeekID: 3, count: 4},
+		{sinceWeekID: 4, count: 3},
+		{sinceWeekID: 5, count: 0},
+	} {
+		if count := log.countSince(tc.sinceWeekID); count != tc.count {
+			t.Errorf("%d events since week %d, expected %d", count, tc.sinceWeekID, tc.count)
+		}
+	}
+}
+
+func TestSavedPeopleInLastWeeks(t *testing.T) {
+	playground := NewPlayground(DefaultConfig(), 1)
+	strategy := &scriptedStrategy{}
+	playground.AddCitizens(strategy, 2)
+	citizen, other := playground.Citizens[0], playground.Citizens[1]
+	strategy.actions = func(observation *Observation, food Food) []Action {
+		observation.People()
+		return []Action{
+			{ActionType: ActionTypeEat, Amount: food.Amount / 2, Destination: citizen.id},
+			{ActionType: ActionTypeEat, Amount: food.Amount - food.Amount/2, Destination: other.id},
+		}
+	}
+
+	for _, weekID := range []uint{2, 5} {
+		playground.weekID = weekID
+		citizen.HasEnergy, citizen.HadEat = 0, 0
+		other.HasEnergy, other.HadEat = 0, 0
+		playground.handleFood(citizen, &Food{Amount: 2000})
+	}
+
+	playground.weekID = 6
+	for _, tc := range []struct {
+		weeks uint
+		count uint
+	}{
+		{weeks: 1, count: 0},
+		{weeks: 2, count: 1},
+		{weeks: 5, count: 2},
+		{weeks: 100, count: 2},
+	} {
+		if saved, wasSaved := citizen.SavedPeopleInLastWeeks(tc.weeks), other.WasSavedTimesInLastWeeks(tc.weeks); saved != tc.count || wasSaved != tc.count {
+			t.Errorf("in the last %d weeks: saved %d, was saved %d, expected %d", tc.weeks, saved, wasSaved, tc.count)
+		}
+	}
+	if citizen.SavedPeople != 2 || other.WasSavedTimes != 2 {
+		t.Errorf("saved %d, was saved %d in total, expected 2", citizen.SavedPeople, other.WasSavedTimes)
+	}
+}
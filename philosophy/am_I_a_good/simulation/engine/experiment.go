@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Experiment runs the same scenario multiple times ("tries") in parallel.
+type Experiment struct {
+	Config Config
+	Tries  uint
+	Weeks  uint
+
+	// Seed is the master seed, the seed of each try is derived
+	// from it by TrySeed.
+	Seed int64
+
+	// OnlyTries restricts the experiment to the given try indexes
+	// (e.g. to replay a single try). All tries are run if it is empty.
+	OnlyTries []uint
+
+	// Parallel is the amount of tries run simultaneously (the amount
+	// of CPUs if zero).
+	Parallel int
+
+	// Populate fills a fresh playground with citizens. Required.
+	Populate func(playground *Playground)
+
+	// OnStart is called after the playground was populated.
+	// Calls are serialized between tries.
+	OnStart func(tryIdx uint, playground *Playground)
+
+	// OnWeek is called after each week is iterated.
+	// Calls are NOT serialized between tries.
+	OnWeek func(tryIdx uint, week uint, playground *Playground)
+
+	// OnFinish is called after all the weeks were iterated, before
+	// the strategy states of the citizens are finished (see
+	// CitizenStateFinalizer). Calls are serialized between tries.
+	OnFinish func(tryIdx uint, playground *Playground)
+}
+
+// TryIndexes returns the indexes of the tries to be run.
+func (experiment *Experiment) TryIndexes() []uint {
+	if len(experiment.OnlyTries) > 0 {
+		return experiment.OnlyTries
+	}
+	result := make([]uint, experiment.Tries)
+	for tryIdx := range result {
+		result[tryIdx] = uint(tryIdx)
+	}
+	return result
+}
+
+// Run executes all the tries and waits until they finish.
+func (experiment *Experiment) Run() {
+	parallel := experiment.Parallel
+	if parallel <= 0 {
+		parallel = runtime.NumCPU()
+	}
+
+	tryIndexes := make(chan uint)
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	for workerIdx := 0; workerIdx < parallel; workerIdx++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for tryIdx := range tryIndexes {
+				experiment.runTry(tryIdx, &mutex)
+			}
+		}()
+	}
+	for _, tryIdx := range experiment.TryIndexes() {
+		tryIndexes <- tryIdx
+	}
+	close(tryIndexes)
+	wg.Wait()
+}
+
+func (experiment *Experiment) runTry(tryIdx uint, mutex *sync.Mutex) {
+	playground := NewPlayground(experiment.Config, TrySeed(experiment.Seed, tryIdx))
+	experiment.Populate(playground)
+
+	if experiment.OnStart != nil {
+		mutex.Lock()
+		experiment.OnStart(tryIdx, playground)
+		mutex.Unlock()
+	}
+
+	for week := uint(0); week < experiment.Weeks; week++ {
+		playground.IterateWeek()
+		if experiment.OnWeek != nil {
+			experiment.OnWeek(tryIdx, week, playground)
+		}
+	}
+
+	if experiment.OnFinish != nil {
+		mutex.Lock()
+		experiment.OnFinish(tryIdx, playground)
+		mutex.Unlock()
+	}
+	playground.finishStrategyStates()
+}
@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+// eatEverything eats the whole portion, so the survivors depend
+// only on the random order of the citizens.
+func eatEverything(observation *Observation, food Food) []Action {
+	return []Action{{ActionType: ActionTypeEat, Amount: food.Amount, Destination: observation.Self.ID()}}
+}
+
+func TestExperimentParallel(t *testing.T) {
+	run := func(parallel int) map[uint]uint {
+		cfg := DefaultConfig()
+		cfg.AmountOfPortions = 30
+		population := map[uint]uint{}
+		experiment := &Experiment{
+			Config:   cfg,
+			Tries:    8,
+			Weeks:    100,
+			Seed:     3,
+			Parallel: parallel,
+			Populate: func(playground *Playground) {
+				playground.AddCitizens(&scriptedStrategy{actions: eatEverything}, 30)
+			},
+			OnFinish: func(tryIdx uint, playground *Playground) {
+				population[tryIdx] = uint(len(playground.Citizens))
+			},
+		}
+		experiment.Run()
+		return population
+	}
+
+	sequential := run(1)
+	if len(sequential) != 8 {
+		t.Fatalf("%d tries finished instead of 8", len(sequential))
+	}
+	for _, parallel := range []int{0, 3, 100} {
+		if result := run(parallel); !reflect.DeepEqual(result, sequential) {
+			t.Errorf("the tries run %d at a time %v differ from the tries run one by one %v", parallel, result, sequential)
+		}
+	}
+}
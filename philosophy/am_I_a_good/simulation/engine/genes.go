@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// Genotype is an optional interface of a Strategy composed of discrete
+// cultural genes. Genotypes are transmitted gene by gene and mutate
+// (see Config.EnableGeneBorrowing and Config.GeneMutationRate).
+type Genotype interface {
+	Strategy
+
+	// Genes returns the alleles of the genes.
+	Genes() []uint
+
+	// Alleles returns the amount of possible alleles of each gene.
+	Alleles() []uint
+
+	// WithGenes returns the genotype with the given alleles.
+	WithGenes(genes []uint) Genotype
+}
+
+// GenotypeKey returns the key identifying the genes of the genotype:
+// the genotypes with equal keys are the same culture on a playground.
+func GenotypeKey(genotype Genotype) string {
+	return fmt.Sprint(genotype.Genes())
+}
+
+// internGenotype returns the genotype with the same genes which is
+// already on the playground (so that the citizens with the same genes
+// share the same Strategy), or registers the given one.
+func (playground *Playground) internGenotype(genotype Genotype) Genotype {
+	key := GenotypeKey(genotype)
+	if existing, ok := playground.genotypes[key]; ok {
+		return existing
+	}
+	if playground.genotypes == nil {
+		playground.genotypes = map[string]Genotype{}
+	}
+	playground.genotypes[key] = genotype
+	return genotype
+}
+
+// transmit returns the strategy a citizen following "current"
+// (nil for a graduating child) acquires when it learns "source".
+// For genotypes a changing citizen borrows a single random gene if
+// Config.EnableGeneBorrowing is set, and every gene may mutate.
+func (playground *Playground) transmit(current Strategy, source Strategy) Strategy {
+	cfg := &playground.Config
+	sourceGenotype, ok := source.(Genotype)
+	if !ok {
+		return source
+	}
+
+	genes := append([]uint{}, sourceGenotype.Genes()...)
+	if currentGenotype, ok := current.(Genotype); ok && cfg.EnableGeneBorrowing {
+		currentGenes := currentGenotype.Genes()
+		if len(currentGenes) != len(genes) {
+			panic(fmt.Sprintf("incompatible genotypes: %T and %T", current, source))
+		}
+		geneIdx := playground.RandUintn(uint(len(genes)))
+		borrowed := genes[geneIdx]
+		genes = append(genes[:0], currentGenes...)
+		genes[geneIdx] = borrowed
+	}
+
+	if cfg.GeneMutationRate > 0 {
+		alleles := sourceGenotype.Alleles()
+		for geneIdx := range genes {
+			if playground.Rand.Float64() < cfg.GeneMutationRate {
+				genes[geneIdx] = playground.RandUintn(alleles[geneIdx])
+				playground.weekStats.GeneMutations++
+			}
+		}
+	}
+
+	return playground.internGenotype(sourceGenotype.WithGenes(genes))
+}
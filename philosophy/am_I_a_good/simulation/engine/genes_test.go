@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"testing"
+)
+
+// testGenotype is a genotype of two genes of three alleles each.
+type testGenotype struct {
+	scriptedStrategy
+	genes [2]uint
+}
+
+func (genotype *testGenotype) Genes() []uint {
+	return genotype.genes[:]
+}
+
+func (genotype *testGenotype) Alleles() []uint {
+	return []uint{3, 3}
+}
+
+func (genotype *testGenotype) WithGenes(genes []uint) Genotype {
+	result := &testGenotype{}
+	copy(result.genes[:], genes)
+	return result
+}
+
+func genesPlayground(borrowing bool, mutationRate float64) *Playground {
+	cfg := DefaultConfig()
+	cfg.EnableGeneBorrowing = borrowing
+	cfg.GeneMutationRate = mutationRate
+	return NewPlayground(cfg, 1)
+}
+
+func TestTransmit(t *testing.T) {
+	current, source := &testGenotype{genes: [2]uint{0, 0}}, &testGenotype{genes: [2]uint{2, 2}}
+
+	// a non-genotype is transmitted as is
+	playground := genesPlayground(true, 1)
+	other := &scriptedStrategy{}
+	if playground.transmit(current, other) != other {
+		t.Errorf("a non-genotype strategy is changed")
+	}
+
+	// the whole genotype is copied without borrowing and mutations,
+	// the same genes share the same Strategy
+	playground = genesPlayground(false, 0)
+	copied := playground.transmit(current, source)
+	if GenotypeKey(copied.(Genotype)) != GenotypeKey(source) || playground.transmit(nil, source) != copied {
+		t.Errorf("unexpected transmitted genotype: %v", copied)
+	}
+
+	// a single gene is borrowed
+	playground = genesPlayground(true, 0)
+	seen := map[string]bool{}
+	for try := 0; try < 100; try++ {
+		seen[GenotypeKey(playground.transmit(current, source).(Genotype))] = true
+	}
+	if len(seen) != 2 || !seen["[2 0]"] || !seen["[0 2]"] {
+		t.Errorf("unexpected borrowed genotypes: %v", seen)
+	}
+
+	// every gene mutates within the alleles
+	playground = genesPlayground(false, 1)
+	seen = map[string]bool{}
+	for try := 0; try < 100; try++ {
+		genes := playground.transmit(nil, source).(Genotype).Genes()
+		if genes[0] >= 3 || genes[1] >= 3 {
+			t.Fatalf("an invalid allele: %v", genes)
+		}
+		seen[GenotypeKey(playground.transmit(nil, source).(Genotype))] = true
+	}
+	if len(seen) != 9 || playground.weekStats.GeneMutations != 400 {
+		t.Errorf("%d genotypes after %d mutations", len(seen), playground.weekStats.GeneMutations)
+	}
+}
@@ -0,0 +1,202 @@
+package engine
+
+// Rumor is a report about a single observation of a citizen's behavior
+// (see Config.EnableGossip).
+type Rumor struct {
+	ID      uint64
+	Subject *Citizen
+
+	// Greedy is true if the subject was reported to keep the surplus
+	// to itself while somebody was hungry, and false if it was
+	// reported to save somebody.
+	Greedy bool
+
+	// Hops is the amount of retellings since the witness.
+	Hops uint
+}
+
+// Reputation is what a citizen heard about another citizen through gossip.
+type Reputation struct {
+	GreedyReports uint
+	HelpReports   uint
+}
+
+// GossipReporter is an optional interface of a Strategy which decides
+// what to tell others when retelling a rumor: whether the subject was
+// greedy. Strategies which do not implement it retell rumors honestly.
+type GossipReporter interface {
+	ReportRumor(observation *Observation, subject CitizenView, greedy bool) bool
+}
+
+type heardRumor struct {
+	Rumor
+	HeardAtWeekID uint
+}
+
+// gossipState is the per-citizen state of the gossip model.
+type gossipState struct {
+	links      []*Citizen
+	reputation map[*Citizen]*Reputation
+	heard      map[uint64]uint // rumor ID -> week ID it was heard
+	toRetell   []heardRumor
+
+	greedyWitness *Citizen
+	helpWitness   *Citizen
+}
+
+// Reputation returns what the citizen heard about the other citizen.
+// It is always empty if Config.EnableGossip is false.
+func (citizen *Citizen) Reputation(other *Citizen) Reputation {
+	reputation := citizen.gossip.reputation[other]
+	if reputation == nil {
+		return Reputation{}
+	}
+	return *reputation
+}
+
+// observeGreed makes a random hungry citizen a witness of the greed.
+func (playground *Playground) observeGreed(citizen *Citizen) {
+	hungryCitizens := playground.HungryCitizens()
+	if len(hungryCitizens) == 0 {
+		return
+	}
+	witness := hungryCitizens[playground.RandUintn(uint(len(hungryCitizens)))]
+	if witness == citizen {
+		return
+	}
+	citizen.gossip.greedyWitness = witness
+}
+
+func (playground *Playground) observeHelp(citizen *Citizen, witness *Citizen) {
+	citizen.gossip.helpWitness = witness
+}
+
+// hear makes the citizen learn the rumor (unless it already did).
+func (playground *Playground) hear(citizen *Citizen, rumor Rumor) {
+	if citizen.isDead || rumor.Subject.isDead || rumor.Subject == citizen {
+		return
+	}
+	if citizen.gossip.heard == nil {
+		citizen.gossip.heard = map[uint64]uint{}
+		citizen.gossip.reputation = map[*Citizen]*Reputation{}
+	}
+	if _, ok := citizen.gossip.heard[rumor.ID]; ok {
+		return
+	}
+	citizen.gossip.heard[rumor.ID] = playground.weekID
+
+	reputation := citizen.gossip.reputation[rumor.Subject]
+	if reputation == nil {
+		reputation = &Reputation{}
+		citizen.gossip.reputation[rumor.Subject] = reputation
+	}
+	if rumor.Greedy {
+		reputation.GreedyReports++
+	} else {
+		reputation.HelpReports++
+	}
+
+	if rumor.Hops < playground.Config.GossipMaxHops {
+		citizen.gossip.toRetell = append(citizen.gossip.toRetell, heardRumor{
+			Rumor:         rumor,
+			HeardAtWeekID: playground.weekID,
+		})
+	}
+}
+
+func (playground *Playground) newRumor(subject *Citizen, greedy bool) Rumor {
+	playground.lastRumorID++
+	return Rumor{
+		ID:      playground.lastRumorID,
+		Subject: subject,
+		Greedy:  greedy,
+	}
+}
+
+// linkRelatives makes the two citizens gossip to each other.
+func (playground *Playground) linkRelatives(a, b *Citizen) {
+	a.gossip.links = append(a.gossip.links, b)
+	b.gossip.links = append(b.gossip.links, a)
+}
+
+// refreshLinks drops the links to the dead and tops up the random links.
+func (playground *Playground) refreshLinks(citizen *Citizen) {
+	links := citizen.gossip.links[:0]
+	for _, link := range citizen.gossip.links {
+		if !link.isDead {
+			links = append(links, link)
+		}
+	}
+	citizen.gossip.links = links
+
+	if len(playground.Citizens) < 2 {
+		return
+	}
+	for tries := 0; uint(len(citizen.gossip.links)) < playground.Config.GossipLinks && tries < 3; tries++ {
+		link := playground.Citizens[playground.RandUintn(uint(len(playground.Citizens)))]
+		if link == citizen {
+			continue
+		}
+		citizen.gossip.links = append(citizen.gossip.links, link)
+	}
+}
+
+// spreadGossip turns the observations of the week into rumors and makes
+// every citizen retell the rumors it heard at least GossipDelayInWeeks ago.
+func (playground *Playground) spreadGossip() {
+	cfg := &playground.Config
+
+	for _, citizen := range playground.Citizens {
+		if witness := citizen.gossip.greedyWitness; witness != nil {
+			playground.hear(witness, playground.newRumor(citizen, true))
+			citizen.gossip.greedyWitness = nil
+		}
+		if witness := citizen.gossip.helpWitness; witness != nil {
+			playground.hear(witness, playground.newRumor(citizen, false))
+			citizen.gossip.helpWitness = nil
+		}
+	}
+
+	for _, citizen := range playground.Citizens {
+		playground.refreshLinks(citizen)
+
+		var postponed []heardRumor
+		toRetell := citizen.gossip.toRetell
+		citizen.gossip.toRetell = nil
+		for _, rumor := range toRetell {
+			if rumor.HeardAtWeekID+cfg.GossipDelayInWeeks > playground.weekID {
+				postponed = append(postponed, rumor)
+				continue
+			}
+			told := rumor.Rumor
+			told.Hops++
+			if reporter, ok := citizen.Strategy.(GossipReporter); ok {
+				told.Greedy = reporter.ReportRumor(playground.observe(citizen), newCitizenView(told.Subject), told.Greedy)
+			}
+			for _, link := range citizen.gossip.links {
+				heard := told
+				if playground.Rand.Float64() < cfg.GossipErrorRate {
+					heard.Greedy = !heard.Greedy
+				}
+				playground.hear(link, heard)
+			}
+		}
+		citizen.gossip.toRetell = append(postponed, citizen.gossip.toRetell...)
+
+		// forgetting the IDs of the rumors which cannot come back anymore
+		forgetBefore := int64(playground.weekID) - int64((cfg.GossipDelayInWeeks+1)*(cfg.GossipMaxHops+1))
+		for rumorID, weekID := range citizen.gossip.heard {
+			if int64(weekID) < forgetBefore {
+				delete(citizen.gossip.heard, rumorID)
+			}
+		}
+	}
+}
+
+// forgetGossip removes the dead citizen from the reputations heard by
+// everybody else.
+func (playground *Playground) forgetGossip(deadCitizen *Citizen) {
+	for _, citizen := range playground.Citizens {
+		delete(citizen.gossip.reputation, deadCitizen)
+	}
+}
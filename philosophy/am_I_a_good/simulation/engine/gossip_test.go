@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"testing"
+)
+
+func gossipPlayground(configure func(cfg *Config)) *Playground {
+	cfg := DefaultConfig()
+	cfg.EnableGossip = true
+	cfg.GossipLinks = 0
+	cfg.GossipDelayInWeeks = 1
+	cfg.GossipMaxHops = 3
+	if configure != nil {
+		configure(&cfg)
+	}
+	playground := NewPlayground(cfg, 1)
+	playground.AddCitizens(&scriptedStrategy{}, 6)
+	return playground
+}
+
+func TestGossipSpread(t *testing.T) {
+	playground := gossipPlayground(nil)
+	subject, chain := playground.Citizens[0], playground.Citizens[1:]
+	for idx := 1; idx < len(chain); idx++ {
+		playground.linkRelatives(chain[idx-1], chain[idx])
+	}
+
+	playground.hear(chain[0], playground.newRumor(subject, true))
+
+	// the rumor moves one link a week and is retold at most GossipMaxHops times
+	for week := 1; week <= 6; week++ {
+		playground.weekID++
+		playground.spreadGossip()
+		for idx, citizen := range chain {
+			heard := uint(0)
+			if idx <= week && uint(idx) <= playground.Config.GossipMaxHops {
+				heard = 1
+			}
+			if reputation := citizen.Reputation(subject); reputation != (Reputation{GreedyReports: heard}) {
+				t.Errorf("week %d: citizen #%d heard %+v, expected %d greedy reports", week, idx, reputation, heard)
+			}
+		}
+	}
+}
+
+func TestGossipErrors(t *testing.T) {
+	playground := gossipPlayground(func(cfg *Config) { cfg.GossipErrorRate = 1 })
+	subject, teller, listener := playground.Citizens[0], playground.Citizens[1], playground.Citizens[2]
+	playground.linkRelatives(teller, listener)
+
+	playground.hear(teller, playground.newRumor(subject, true))
+	playground.weekID++
+	playground.spreadGossip()
+
+	if reputation := listener.Reputation(subject); reputation != (Reputation{HelpReports: 1}) {
+		t.Errorf("the misheard rumor is %+v", reputation)
+	}
+	if reputation := teller.Reputation(subject); reputation != (Reputation{GreedyReports: 1}) {
+		t.Errorf("the witness remembers %+v", reputation)
+	}
+}
+
+func TestGossipObservations(t *testing.T) {
+	playground := gossipPlayground(nil)
+	strategy := &scriptedStrategy{}
+	for _, citizen := range playground.Citizens {
+		citizen.Strategy = strategy
+		citizen.HasEnergy = 0
+	}
+	greedy, generous, saved := playground.Citizens[0], playground.Citizens[1], playground.Citizens[2]
+	greedy.HasEnergy, generous.HasEnergy = playground.Config.RequiredEnergy, playground.Config.RequiredEnergy
+
+	strategy.actions = func(observation *Observation, food Food) []Action {
+		if observation.Self.ID() == generous.id {
+			observation.HungryPeople()
+			return []Action{{ActionType: ActionTypeEat, Amount: food.Amount, Destination: saved.id}}
+		}
+		return []Action{{ActionType: ActionTypeEat, Amount: food.Amount, Destination: observation.Self.ID()}}
+	}
+	playground.handleFood(greedy, &Food{Amount: 2000})
+	playground.handleFood(generous, &Food{Amount: 2000})
+	playground.weekID++
+	playground.spreadGossip()
+
+	var greedyReports, helpReports uint
+	for _, citizen := range playground.Citizens {
+		greedyReports += citizen.Reputation(greedy).GreedyReports
+		helpReports += citizen.Reputation(generous).HelpReports
+	}
+	if greedyReports != 1 || saved.Reputation(generous) != (Reputation{HelpReports: 1}) || helpReports != 1 {
+		t.Errorf("witnessed greed %d times and help %d times (by the saved: %+v), expected once each",
+			greedyReports, helpReports, saved.Reputation(generous))
+	}
+}
+
+func TestGossipForgetsTheDead(t *testing.T) {
+	playground := gossipPlayground(nil)
+	subject, listener := playground.Citizens[0], playground.Citizens[1]
+	playground.hear(listener, playground.newRumor(subject, true))
+
+	playground.RemoveCitizen(subject)
+	if reputation := listener.Reputation(subject); reputation != (Reputation{}) {
+		t.Errorf("the dead is remembered as %+v", reputation)
+	}
+	playground.hear(listener, playground.newRumor(subject, true))
+	if reputation := listener.Reputation(subject); reputation != (Reputation{}) {
+		t.Errorf("a rumor about the dead is heard: %+v", reputation)
+	}
+}
+
+func TestGossipLinksGraduates(t *testing.T) {
+	playground := gossipPlayground(nil)
+	parent := playground.Citizens[0]
+	parent.Children = append(parent.Children, &Child{Person: Person{Playground: playground}, Parent: parent})
+	parent.Children[0].Graduate()
+
+	graduate := playground.Citizens[len(playground.Citizens)-1]
+	if len(parent.gossip.links) != 1 || parent.gossip.links[0] != graduate ||
+		len(graduate.gossip.links) != 1 || graduate.gossip.links[0] != parent {
+		t.Errorf("the graduate and the parent are not linked: %v, %v", parent.gossip.links, graduate.gossip.links)
+	}
+}
@@ -0,0 +1,156 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// InheritancePolicy defines who receives the energy and the hidden food
+// of a dead citizen (the part not bequeathed by a Testator).
+type InheritancePolicy uint
+
+const (
+	// InheritancePolicyLost lets the estate vanish.
+	InheritancePolicyLost = InheritancePolicy(iota)
+
+	// InheritancePolicyChildren splits the estate equally between
+	// the children, or between the family if there are no children.
+	InheritancePolicyChildren
+
+	// InheritancePolicyFamily splits the estate equally between
+	// the children and the citizens of the family.
+	InheritancePolicyFamily
+
+	// InheritancePolicyCommunity puts the estate to the budget of
+	// the Institution.
+	InheritancePolicyCommunity
+)
+
+func (policy InheritancePolicy) String() string {
+	switch policy {
+	case InheritancePolicyLost:
+		return "lost"
+	case InheritancePolicyChildren:
+		return "children"
+	case InheritancePolicyFamily:
+		return "family"
+	case InheritancePolicyCommunity:
+		return "community"
+	}
+	return "unknown"
+}
+
+// ParseInheritancePolicy parses the output of InheritancePolicy.String.
+func ParseInheritancePolicy(s string) (InheritancePolicy, error) {
+	for policy := InheritancePolicyLost; policy <= InheritancePolicyCommunity; policy++ {
+		if policy.String() == s {
+			return policy, nil
+		}
+	}
+	return InheritancePolicyLost, fmt.Errorf("unknown inheritance policy '%s'", s)
+}
+
+func (policy InheritancePolicy) MarshalText() ([]byte, error) {
+	return []byte(policy.String()), nil
+}
+
+func (policy *InheritancePolicy) UnmarshalText(b []byte) error {
+	parsed, err := ParseInheritancePolicy(string(b))
+	if err != nil {
+		return err
+	}
+	*policy = parsed
+	return nil
+}
+
+// Bequest is a share of the estate left to a person by a Testator.
+type Bequest struct {
+	Heir  PersonID
+	Share float64
+}
+
+// Testator is an optional interface of a Strategy which decides who
+// inherits the estate of the citizen (see Config.EnableBequests).
+// The shares should sum up to at most 1; the rest of the estate
+// is inherited according to Config.InheritancePolicy.
+type Testator interface {
+	Will(observation *Observation) []Bequest
+}
+
+// deaths is the death phase of a week: it hands over the estates and
+// the children of the citizens removed since the previous death phase.
+func (playground *Playground) deaths() {
+	dead := playground.dead
+	playground.dead = nil
+	for _, deadCitizen := range dead {
+		playground.inherit(deadCitizen)
+		playground.handleOrphans(deadCitizen)
+	}
+}
+
+func (playground *Playground) inherit(deadCitizen *Citizen) {
+	cfg := &playground.Config
+	estate := deadCitizen.HasEnergy + deadCitizen.takeAllHiddenFood()
+	deadCitizen.HasEnergy = 0
+	if estate == 0 {
+		return
+	}
+	rest := estate
+
+	if testator, ok := deadCitizen.Strategy.(Testator); ok && cfg.EnableBequests {
+		totalShare := float64(0)
+		observation := playground.observe(deadCitizen)
+		for _, bequest := range testator.Will(observation) {
+			if bequest.Share < 0 {
+				panic(fmt.Sprintf("negative share: %+v (%T)", bequest, deadCitizen.Strategy))
+			}
+			totalShare += bequest.Share
+			if totalShare > 1+1e-9 {
+				panic(fmt.Sprintf("bequeathed more than the estate: %f (%T)", totalShare, deadCitizen.Strategy))
+			}
+			amount := uint(float64(estate) * bequest.Share)
+			if amount > rest {
+				amount = rest
+			}
+			heir := observation.person(bequest.Heir)
+			if amount == 0 || heir == &deadCitizen.Person || (heir.Citizen != deadCitizen && heir.Citizen.isDead) {
+				continue
+			}
+			heir.HasEnergy += amount
+			rest -= amount
+			playground.weekStats.Inherited += amount
+		}
+	}
+	if rest == 0 {
+		return
+	}
+
+	var heirs []*Person
+	switch cfg.InheritancePolicy {
+	case InheritancePolicyLost:
+	case InheritancePolicyChildren, InheritancePolicyFamily:
+		for _, child := range deadCitizen.Children {
+			heirs = append(heirs, &child.Person)
+		}
+		if (cfg.InheritancePolicy == InheritancePolicyFamily || len(heirs) == 0) && deadCitizen.Family != nil {
+			for _, relative := range deadCitizen.Family.Citizens {
+				heirs = append(heirs, &relative.Person)
+			}
+		}
+	case InheritancePolicyCommunity:
+		playground.Institution.Budget += rest
+		playground.weekStats.Inherited += rest
+		return
+	default:
+		panic(fmt.Sprintf("unknown inheritance policy: %v", cfg.InheritancePolicy))
+	}
+	if len(heirs) == 0 {
+		playground.weekStats.InheritanceLost += rest
+		return
+	}
+	share := rest / uint(len(heirs))
+	for _, heir := range heirs {
+		heir.HasEnergy += share
+	}
+	playground.weekStats.Inherited += share * uint(len(heirs))
+	playground.weekStats.InheritanceLost += rest - share*uint(len(heirs))
+}
@@ -0,0 +1,145 @@
+package engine
+
+import (
+	"testing"
+)
+
+// scriptedTestator leaves the bequests built by the test.
+type scriptedTestator struct {
+	scriptedStrategy
+	will func(observation *Observation) []Bequest
+}
+
+func (strategy *scriptedTestator) Will(observation *Observation) []Bequest {
+	if strategy.will == nil {
+		return nil
+	}
+	return strategy.will(observation)
+}
+
+// inheritancePlayground returns a playground with a dead-to-be citizen
+// of a child, its relative and a stranger, everybody without energy
+// except the dead-to-be, who has 3000 energy and 1000 hidden food.
+func inheritancePlayground(policy InheritancePolicy) (*Playground, *scriptedTestator) {
+	cfg := DefaultConfig()
+	cfg.InheritancePolicy = policy
+	cfg.EnableBequests = true
+	cfg.EnableInstitution = policy == InheritancePolicyCommunity
+	cfg.RedistributionPolicy = RedistributionPolicyHungry
+	playground := NewPlayground(cfg, 1)
+	testator := &scriptedTestator{}
+	playground.AddCitizens(testator, 2)
+	playground.AddCitizens(&scriptedStrategy{}, 1)
+	citizen := playground.Citizens[0]
+	citizen.HasEnergy = cfg.CreateBabyEnergy
+	citizen.CreateBaby()
+	for _, person := range playground.People() {
+		person.HasEnergy, person.OwnsFood = 0, 0
+	}
+	citizen.HasEnergy = 3000
+	citizen.hide(1000)
+	return playground, testator
+}
+
+func TestInheritancePolicies(t *testing.T) {
+	for _, tc := range []struct {
+		policy InheritancePolicy
+		child  uint
+		family uint
+		budget uint
+		lost   uint
+	}{
+		{policy: InheritancePolicyLost, lost: 4000},
+		{policy: InheritancePolicyChildren, child: 4000},
+		{policy: InheritancePolicyFamily, child: 2000, family: 2000},
+		{policy: InheritancePolicyCommunity, budget: 4000},
+	} {
+		t.Run(tc.policy.String(), func(t *testing.T) {
+			playground, _ := inheritancePlayground(tc.policy)
+			citizen, relative, stranger := playground.Citizens[0], playground.Citizens[1], playground.Citizens[2]
+			child := citizen.Children[0]
+
+			playground.RemoveCitizen(citizen)
+			if child.HasEnergy != 0 || citizen.HasEnergy != 3000 {
+				t.Errorf("the estate is handed over before the death phase")
+			}
+			playground.deaths()
+			if child.HasEnergy != tc.child || relative.HasEnergy != tc.family || stranger.HasEnergy != 0 {
+				t.Errorf("the child got %d, the relative %d, the stranger %d",
+					child.HasEnergy, relative.HasEnergy, stranger.HasEnergy)
+			}
+			if tc.budget > 0 && playground.Institution.Budget != tc.budget {
+				t.Errorf("the budget is %d instead of %d", playground.Institution.Budget, tc.budget)
+			}
+			stats := playground.weekStats
+			if stats.Inherited != 4000-tc.lost || stats.InheritanceLost != tc.lost {
+				t.Errorf("unexpected stats: %+v", stats)
+			}
+			if citizen.HasEnergy != 0 || citizen.OwnsFood != 0 {
+				t.Errorf("the dead still has %d energy and %d hidden food", citizen.HasEnergy, citizen.OwnsFood)
+			}
+		})
+	}
+
+	// without children the estate goes to the family
+	playground, _ := inheritancePlayground(InheritancePolicyChildren)
+	citizen, relative := playground.Citizens[0], playground.Citizens[1]
+	citizen.Children[0].Die()
+	playground.RemoveCitizen(citizen)
+	playground.deaths()
+	if relative.HasEnergy != 4000 {
+		t.Errorf("the relative got %d instead of 4000", relative.HasEnergy)
+	}
+}
+
+func TestBequests(t *testing.T) {
+	playground, testator := inheritancePlayground(InheritancePolicyChildren)
+	citizen, stranger := playground.Citizens[0], playground.Citizens[2]
+	child := citizen.Children[0]
+	testator.will = func(observation *Observation) []Bequest {
+		observation.People()
+		return []Bequest{
+			{Heir: stranger.id, Share: 0.5},
+			{Heir: citizen.id, Share: 0.5},
+		}
+	}
+
+	// the share of the dead itself goes by the policy
+	playground.RemoveCitizen(citizen)
+	playground.deaths()
+	if stranger.HasEnergy != 2000 || child.HasEnergy != 2000 {
+		t.Errorf("the stranger got %d, the child %d; expected 2000 and 2000", stranger.HasEnergy, child.HasEnergy)
+	}
+
+	playground, testator = inheritancePlayground(InheritancePolicyLost)
+	citizen = playground.Citizens[0]
+	testator.will = func(observation *Observation) []Bequest {
+		observation.People()
+		return []Bequest{{Heir: playground.Citizens[1].id, Share: 0.6}, {Heir: playground.Citizens[2].id, Share: 0.6}}
+	}
+	playground.RemoveCitizen(citizen)
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic on bequeathing more than the estate")
+		}
+	}()
+	playground.deaths()
+}
+
+func TestDeathPhase(t *testing.T) {
+	playground := orphanPlayground(OrphanPolicyRelatives)
+	parent, relative := playground.Citizens[0], playground.Citizens[1]
+	child := parent.Children[0]
+
+	// the children of the dead grow up until they are handed over
+	playground.RemoveCitizen(parent)
+	playground.aging()
+	if child.AgeInWeeks != 1 || child.Parent != parent {
+		t.Errorf("the age is %d, the parent is %p", child.AgeInWeeks, child.Parent)
+	}
+	playground.deaths()
+	playground.aging()
+	if child.AgeInWeeks != 2 || child.Parent != relative {
+		t.Errorf("the age is %d, the parent is %p", child.AgeInWeeks, child.Parent)
+	}
+}
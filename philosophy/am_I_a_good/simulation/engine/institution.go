@@ -0,0 +1,152 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+)
+
+type RedistributionPolicy uint
+
+const (
+	RedistributionPolicyUndefined = RedistributionPolicy(iota)
+
+	// RedistributionPolicyHungry saves the hungry, the closest to
+	// survival first; the rest of the budget is kept for later.
+	RedistributionPolicyHungry
+
+	// RedistributionPolicyChildren splits the budget equally between
+	// the children.
+	RedistributionPolicyChildren
+
+	// RedistributionPolicyEqually splits the budget equally between
+	// all the people.
+	RedistributionPolicyEqually
+)
+
+func (policy RedistributionPolicy) String() string {
+	switch policy {
+	case RedistributionPolicyUndefined:
+		return "undefined"
+	case RedistributionPolicyHungry:
+		return "hungry"
+	case RedistributionPolicyChildren:
+		return "children"
+	case RedistributionPolicyEqually:
+		return "equally"
+	}
+	return "unknown"
+}
+
+// ParseRedistributionPolicy parses the output of RedistributionPolicy.String.
+func ParseRedistributionPolicy(s string) (RedistributionPolicy, error) {
+	for policy := RedistributionPolicyHungry; policy <= RedistributionPolicyEqually; policy++ {
+		if policy.String() == s {
+			return policy, nil
+		}
+	}
+	return RedistributionPolicyUndefined, fmt.Errorf("unknown redistribution policy '%s'", s)
+}
+
+func (policy RedistributionPolicy) MarshalText() ([]byte, error) {
+	return []byte(policy.String()), nil
+}
+
+func (policy *RedistributionPolicy) UnmarshalText(b []byte) error {
+	parsed, err := ParseRedistributionPolicy(string(b))
+	if err != nil {
+		return err
+	}
+	*policy = parsed
+	return nil
+}
+
+// TaxEvader is an optional interface of a Strategy which decides
+// whether to hide a found food portion from the tax collector
+// (see Config.EnableInstitution).
+type TaxEvader interface {
+	EvadeTax(observation *Observation, food Food) bool
+}
+
+// Institution is a people's budget: it collects a tax from every found
+// food portion and redistributes it every week according to the policy.
+type Institution struct {
+	Budget uint
+}
+
+// collectTax takes the tax from the food portion found by the citizen.
+func (playground *Playground) collectTax(citizen *Citizen, food *Food) {
+	cfg := &playground.Config
+	food.Taxed = true
+	tax := uint(float64(food.Amount) * cfg.TaxRate)
+	if tax == 0 {
+		return
+	}
+
+	if evader, ok := citizen.Strategy.(TaxEvader); ok && evader.EvadeTax(playground.observe(citizen), *food) {
+		if playground.Rand.Float64() >= cfg.AuditProbability {
+			playground.weekStats.TaxEvaded += tax
+			return
+		}
+		citizen.SpottedAsTaxEvader = true
+		playground.weekStats.TaxEvadersCaught++
+		tax += uint(float64(tax) * cfg.AuditFine)
+		if tax > food.Amount {
+			tax = food.Amount
+		}
+	}
+
+	food.Amount -= tax
+	playground.Institution.Budget += tax
+	playground.weekStats.TaxCollected += tax
+}
+
+// redistribute spends the budget according to Config.RedistributionPolicy.
+func (playground *Playground) redistribute() {
+	institution := playground.Institution
+	requiredEnergy := playground.Config.RequiredEnergy
+
+	var recipients []*Person
+	switch playground.Config.RedistributionPolicy {
+	case RedistributionPolicyHungry:
+		for _, person := range playground.People() {
+			if person.TotalEnergy() < requiredEnergy {
+				recipients = append(recipients, person)
+			}
+		}
+		sort.Slice(recipients, func(i, j int) bool {
+			return recipients[i].TotalEnergy() > recipients[j].TotalEnergy()
+		})
+		for _, person := range recipients {
+			toSurvive := requiredEnergy - person.TotalEnergy()
+			if toSurvive > institution.Budget {
+				break
+			}
+			institution.Budget -= toSurvive
+			person.HadEat += toSurvive
+			playground.weekStats.Redistributed += toSurvive
+		}
+		return
+	case RedistributionPolicyChildren:
+		for _, person := range playground.People() {
+			if person.IsChild() {
+				recipients = append(recipients, person)
+			}
+		}
+	case RedistributionPolicyEqually:
+		recipients = playground.People()
+	default:
+		panic(fmt.Sprintf("unknown redistribution policy: %v", playground.Config.RedistributionPolicy))
+	}
+	if len(recipients) == 0 {
+		return
+	}
+	share := institution.Budget / uint(len(recipients))
+	if share == 0 {
+		return
+	}
+	for _, person := range recipients {
+		person.HadEat += share
+	}
+	institution.Budget -= share * uint(len(recipients))
+	playground.weekStats.Redistributed += share * uint(len(recipients))
+}
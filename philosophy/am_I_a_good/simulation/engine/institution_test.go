@@ -0,0 +1,135 @@
+package engine
+
+import (
+	"testing"
+)
+
+// scriptedEvader evades the tax if the test says so.
+type scriptedEvader struct {
+	scriptedStrategy
+	evade bool
+}
+
+func (strategy *scriptedEvader) EvadeTax(*Observation, Food) bool {
+	return strategy.evade
+}
+
+func institutionPlayground(policy RedistributionPolicy) *Playground {
+	cfg := DefaultConfig()
+	cfg.EnableInstitution = true
+	cfg.TaxRate = 0.1
+	cfg.RedistributionPolicy = policy
+	cfg.AuditFine = 2
+	playground := NewPlayground(cfg, 1)
+	playground.AddCitizens(&scriptedEvader{}, 2)
+	return playground
+}
+
+func TestCollectTax(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		evade            bool
+		auditProbability float64
+		tax              uint
+		evaded           uint
+		caught           bool
+	}{
+		{name: "honest", tax: 100},
+		{name: "evaded", evade: true, evaded: 100},
+		{name: "caught", evade: true, auditProbability: 1, tax: 300, caught: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			playground := institutionPlayground(RedistributionPolicyHungry)
+			playground.Config.AuditProbability = tc.auditProbability
+			citizen := playground.Citizens[0]
+			citizen.Strategy.(*scriptedEvader).evade = tc.evade
+
+			food := &Food{Amount: 1000}
+			playground.collectTax(citizen, food)
+			if food.Amount != 1000-tc.tax || playground.Institution.Budget != tc.tax || !food.Taxed {
+				t.Errorf("left %d of the food, the budget is %d, taxed: %v", food.Amount, playground.Institution.Budget, food.Taxed)
+			}
+			stats := playground.weekStats
+			if stats.TaxCollected != tc.tax || stats.TaxEvaded != tc.evaded || (stats.TaxEvadersCaught == 1) != tc.caught {
+				t.Errorf("unexpected stats: %+v", stats)
+			}
+			if citizen.SpottedAsTaxEvader != tc.caught {
+				t.Errorf("spotted as a tax evader: %v, expected %v", citizen.SpottedAsTaxEvader, tc.caught)
+			}
+		})
+	}
+}
+
+func TestRediscoveredFoodIsTaxedOnce(t *testing.T) {
+	playground := institutionPlayground(RedistributionPolicyHungry)
+	playground.Config.HiddenFoodRediscoveryProbability = 1
+	citizen, other := playground.Citizens[0], playground.Citizens[1]
+	for _, c := range playground.Citizens {
+		c.Strategy.(*scriptedEvader).actions = func(observation *Observation, food Food) []Action {
+			return []Action{{ActionType: ActionTypeHide, Amount: food.Amount, Destination: observation.Self.ID()}}
+		}
+	}
+
+	rediscovered := playground.handleFood(citizen, &Food{Amount: 1000})
+	if len(rediscovered) != 1 || rediscovered[0].Amount != 900 || !rediscovered[0].Taxed {
+		t.Fatalf("unexpected rediscovered food: %+v", rediscovered)
+	}
+	playground.handleFood(other, rediscovered[0])
+	if playground.Institution.Budget != 100 {
+		t.Errorf("the budget is %d instead of 100: the rediscovered food is taxed again", playground.Institution.Budget)
+	}
+}
+
+func TestRedistribute(t *testing.T) {
+	for _, tc := range []struct {
+		policy   RedistributionPolicy
+		expected []uint
+		budget   uint
+	}{
+		{
+			// the closest to survival first, while the budget suffices
+			policy:   RedistributionPolicyHungry,
+			expected: []uint{0, 600, 0},
+			budget:   400,
+		},
+		{
+			policy:   RedistributionPolicyChildren,
+			expected: []uint{0, 0, 1000},
+			budget:   0,
+		},
+		{
+			policy:   RedistributionPolicyEqually,
+			expected: []uint{333, 333, 333},
+			budget:   1,
+		},
+	} {
+		t.Run(tc.policy.String(), func(t *testing.T) {
+			playground := institutionPlayground(tc.policy)
+			parent, other := playground.Citizens[0], playground.Citizens[1]
+			parent.HasEnergy = playground.Config.CreateBabyEnergy
+			parent.CreateBaby()
+			child := parent.Children[0]
+			parent.HasEnergy, other.HasEnergy, child.HasEnergy = 0, 400, 2000
+			playground.Institution.Budget = 1000
+
+			playground.redistribute()
+			for idx, person := range []*Person{&parent.Person, &other.Person, &child.Person} {
+				if person.HadEat != tc.expected[idx] {
+					t.Errorf("person #%d got %d instead of %d", idx, person.HadEat, tc.expected[idx])
+				}
+			}
+			if playground.Institution.Budget != tc.budget || playground.weekStats.Redistributed != 1000-tc.budget {
+				t.Errorf("the budget is %d, redistributed %d", playground.Institution.Budget, playground.weekStats.Redistributed)
+			}
+		})
+	}
+
+	for policy := RedistributionPolicyHungry; policy <= RedistributionPolicyEqually; policy++ {
+		if parsed, err := ParseRedistributionPolicy(policy.String()); err != nil || parsed != policy {
+			t.Errorf("unable to parse %v: %v, %v", policy, parsed, err)
+		}
+	}
+	if _, err := ParseRedistributionPolicy("nobody"); err == nil {
+		t.Errorf("expected an error for an unknown policy")
+	}
+}
@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// LearningChannel is a source a child learns its strategy from
+// (see Config.EnableChildhoodLearning).
+type LearningChannel uint
+
+const (
+	LearningChannelParent = LearningChannel(iota)
+	LearningChannelPeers
+	LearningChannelInstitutions
+	endOfLearningChannel
+)
+
+func (channel LearningChannel) String() string {
+	switch channel {
+	case LearningChannelParent:
+		return "parent"
+	case LearningChannelPeers:
+		return "peers"
+	case LearningChannelInstitutions:
+		return "institutions"
+	}
+	return "unknown"
+}
+
+// CultureStats counts how citizens acquired a strategy.
+type CultureStats struct {
+	// Learned is the amount of graduates who acquired the strategy,
+	// by the channel the most of their lessons came from (without
+	// childhood learning every graduate learns from the parent).
+	Learned [endOfLearningChannel]uint
+
+	// ConvertedTo and ConvertedFrom are the amounts of adults who
+	// switched to and from the strategy.
+	ConvertedTo   uint
+	ConvertedFrom uint
+}
+
+// Add adds the other stats to these ones.
+func (stats *CultureStats) Add(other CultureStats) {
+	for channel := range stats.Learned {
+		stats.Learned[channel] += other.Learned[channel]
+	}
+	stats.ConvertedTo += other.ConvertedTo
+	stats.ConvertedFrom += other.ConvertedFrom
+}
+
+// CultureStats returns how the citizens acquired the strategy since
+// the start of the playground.
+func (playground *Playground) CultureStats(strategy Strategy) CultureStats {
+	stats := playground.cultureStats[strategy]
+	if stats == nil {
+		return CultureStats{}
+	}
+	return *stats
+}
+
+func (playground *Playground) cultureStatsOf(strategy Strategy) *CultureStats {
+	if playground.cultureStats == nil {
+		playground.cultureStats = map[Strategy]*CultureStats{}
+	}
+	stats := playground.cultureStats[strategy]
+	if stats == nil {
+		stats = &CultureStats{}
+		playground.cultureStats[strategy] = stats
+	}
+	return stats
+}
+
+type lesson struct {
+	Strategy Strategy
+	Channel  LearningChannel
+}
+
+// prevailingStrategy returns the most common strategy among the citizens,
+// which is what the institutions teach.
+func (playground *Playground) prevailingStrategy() Strategy {
+	count := map[Strategy]uint{}
+	var result Strategy
+	for _, citizen := range playground.Citizens {
+		count[citizen.Strategy]++
+		if result == nil || count[citizen.Strategy] > count[result] {
+			result = citizen.Strategy
+		}
+	}
+	return result
+}
+
+// childhoodLearning gives a lesson to every child whose age is
+// a multiple of Config.LearningIntervalInWeeks. The channel of
+// the lesson is chosen randomly according to the weights.
+func (playground *Playground) childhoodLearning() {
+	cfg := &playground.Config
+	if len(playground.Citizens) == 0 {
+		return
+	}
+
+	var children []*Child
+	if playground.School != nil {
+		children = append(children, playground.School.Children...)
+	}
+	for _, citizen := range playground.Citizens {
+		children = append(children, citizen.Children...)
+	}
+
+	weights := [endOfLearningChannel]float64{
+		LearningChannelParent:       cfg.LearningFromParentWeight,
+		LearningChannelPeers:        cfg.LearningFromPeersWeight,
+		LearningChannelInstitutions: cfg.LearningFromInstitutionsWeight,
+	}
+	totalWeight := float64(0)
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+
+	var prevailingStrategy Strategy
+	for _, child := range children {
+		if child.AgeInWeeks == 0 || child.AgeInWeeks%cfg.LearningIntervalInWeeks != 0 {
+			continue
+		}
+
+		channel := LearningChannelParent
+		r := playground.Rand.Float64() * totalWeight
+		for channel < endOfLearningChannel-1 && r >= weights[channel] {
+			r -= weights[channel]
+			channel++
+		}
+
+		var strategy Strategy
+		switch channel {
+		case LearningChannelParent:
+			strategy = child.graduationParent().Strategy
+		case LearningChannelPeers:
+			strategy = playground.Citizens[playground.RandUintn(uint(len(playground.Citizens)))].Strategy
+		case LearningChannelInstitutions:
+			if prevailingStrategy == nil {
+				prevailingStrategy = playground.prevailingStrategy()
+			}
+			strategy = prevailingStrategy
+		default:
+			panic(fmt.Sprintf("unknown learning channel: %v", channel))
+		}
+		child.lessons = append(child.lessons, lesson{Strategy: strategy, Channel: channel})
+	}
+}
+
+// learnedStrategy returns the strategy the child got the most lessons
+// of (the latest one on a tie) and the channel which gave the most of
+// these lessons. A child without lessons takes the parent's strategy.
+func (child *Child) learnedStrategy() (Strategy, LearningChannel) {
+	if len(child.lessons) == 0 {
+		return child.graduationParent().Strategy, LearningChannelParent
+	}
+	count := map[Strategy]uint{}
+	var result Strategy
+	for _, lesson := range child.lessons {
+		count[lesson.Strategy]++
+		if result == nil || count[lesson.Strategy] >= count[result] {
+			result = lesson.Strategy
+		}
+	}
+	var channels [endOfLearningChannel]uint
+	for _, lesson := range child.lessons {
+		if lesson.Strategy == result {
+			channels[lesson.Channel]++
+		}
+	}
+	channel := LearningChannelParent
+	for candidate := LearningChannelParent; candidate < endOfLearningChannel; candidate++ {
+		if channels[candidate] > channels[channel] {
+			channel = candidate
+		}
+	}
+	return result, channel
+}
@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"testing"
+)
+
+// learningPlayground returns a playground of two citizens of the common
+// strategy and a parent of the rare strategy with a child.
+func learningPlayground(parentWeight, peersWeight, institutionsWeight float64) (*Playground, []Strategy, *Child) {
+	cfg := DefaultConfig()
+	cfg.EnableChildhoodLearning = true
+	cfg.LearningIntervalInWeeks = 10
+	cfg.LearningFromParentWeight = parentWeight
+	cfg.LearningFromPeersWeight = peersWeight
+	cfg.LearningFromInstitutionsWeight = institutionsWeight
+	playground := NewPlayground(cfg, 1)
+	strategies := []Strategy{&scriptedStrategy{}, &scriptedStrategy{}}
+	playground.AddCitizens(strategies[0], 2)
+	playground.AddCitizens(strategies[1], 1)
+	parent := playground.Citizens[2]
+	parent.HasEnergy = cfg.CreateBabyEnergy
+	parent.CreateBaby()
+	return playground, strategies, parent.Children[0]
+}
+
+func TestChildhoodLearning(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		weights  [3]float64
+		strategy int
+		channel  LearningChannel
+	}{
+		{name: "parent", weights: [3]float64{1, 0, 0}, strategy: 1, channel: LearningChannelParent},
+		{name: "institutions", weights: [3]float64{0, 0, 1}, strategy: 0, channel: LearningChannelInstitutions},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			playground, strategies, child := learningPlayground(tc.weights[0], tc.weights[1], tc.weights[2])
+
+			// the lessons are given every LearningIntervalInWeeks only
+			for age := uint(0); age <= 20; age++ {
+				child.AgeInWeeks = age
+				playground.childhoodLearning()
+			}
+			if len(child.lessons) != 2 {
+				t.Fatalf("%d lessons instead of 2", len(child.lessons))
+			}
+			for _, lesson := range child.lessons {
+				if lesson.Strategy != strategies[tc.strategy] || lesson.Channel != tc.channel {
+					t.Errorf("unexpected lesson: %+v", lesson)
+				}
+			}
+
+			// the graduate takes the learned strategy
+			child.Graduate()
+			graduate := playground.Citizens[len(playground.Citizens)-1]
+			if graduate.Strategy != strategies[tc.strategy] {
+				t.Errorf("graduated with %p instead of %p", graduate.Strategy, strategies[tc.strategy])
+			}
+			if stats := playground.CultureStats(strategies[tc.strategy]); stats.Learned[tc.channel] != 1 {
+				t.Errorf("unexpected culture stats: %+v", stats)
+			}
+		})
+	}
+
+	// peers are random citizens
+	playground, strategies, child := learningPlayground(0, 1, 0)
+	seen := map[Strategy]bool{}
+	for age := uint(1); age <= 1000; age++ {
+		child.AgeInWeeks = age * 10
+		playground.childhoodLearning()
+	}
+	for _, lesson := range child.lessons {
+		seen[lesson.Strategy] = true
+		if lesson.Channel != LearningChannelPeers {
+			t.Errorf("unexpected channel: %v", lesson.Channel)
+		}
+	}
+	if len(seen) != len(strategies) {
+		t.Errorf("learned %d strategies from the peers instead of %d", len(seen), len(strategies))
+	}
+}
+
+func TestLearnedStrategy(t *testing.T) {
+	_, strategies, child := learningPlayground(1, 0, 0)
+	common, rare := strategies[0], strategies[1]
+	if strategy, channel := child.learnedStrategy(); strategy != rare || channel != LearningChannelParent {
+		t.Errorf("a child without lessons learned %p from %v instead of the parent's strategy", strategy, channel)
+	}
+
+	child.lessons = []lesson{
+		{Strategy: common, Channel: LearningChannelPeers},
+		{Strategy: rare, Channel: LearningChannelParent},
+		{Strategy: common, Channel: LearningChannelInstitutions},
+		{Strategy: common, Channel: LearningChannelInstitutions},
+	}
+	if strategy, channel := child.learnedStrategy(); strategy != common || channel != LearningChannelInstitutions {
+		t.Errorf("learned %p from %v", strategy, channel)
+	}
+
+	// the latest one on a tie
+	child.lessons = child.lessons[:2]
+	if strategy, _ := child.learnedStrategy(); strategy != rare {
+		t.Errorf("learned %p instead of the latest strategy on a tie", strategy)
+	}
+}
+
+func TestConversionStats(t *testing.T) {
+	playground, strategies, _ := learningPlayground(1, 0, 0)
+	for _, citizen := range playground.Citizens {
+		citizen.ChangeStrategyProbability = 1
+	}
+	playground.changeStrategies()
+	converted := playground.weekStats.StrategySwitches
+	if converted == 0 {
+		t.Fatalf("nobody changed the strategy")
+	}
+	var to, from uint
+	for _, strategy := range strategies {
+		stats := playground.CultureStats(strategy)
+		to += stats.ConvertedTo
+		from += stats.ConvertedFrom
+	}
+	if to != converted || from != converted {
+		t.Errorf("converted %d, but counted %d to and %d from", converted, to, from)
+	}
+}
@@ -0,0 +1,79 @@
+package engine
+
+type Move uint
+
+const (
+	MoveNone = Move(iota)
+	MoveHelped
+	MoveRefused
+)
+
+func (move Move) String() string {
+	switch move {
+	case MoveNone:
+		return "none"
+	case MoveHelped:
+		return "helped"
+	case MoveRefused:
+		return "refused"
+	}
+	return "unknown"
+}
+
+// Relationship is what a citizen personally remembers about another
+// citizen (see Config.EnablePrivateMemory).
+type Relationship struct {
+	// HelpedMe is the amount of times the other citizen gave food to
+	// this citizen or its children.
+	HelpedMe uint
+
+	// RefusedMe is the amount of times the other citizen kept
+	// the surplus food to itself while this citizen was hungry.
+	RefusedMe uint
+
+	TheirLastMove Move
+	MyLastMove    Move
+}
+
+// Relationship returns what the citizen remembers about the other citizen.
+// It is always empty if Config.EnablePrivateMemory is false.
+func (citizen *Citizen) Relationship(other *Citizen) Relationship {
+	relationship := citizen.ledger[other]
+	if relationship == nil {
+		return Relationship{}
+	}
+	return *relationship
+}
+
+func (citizen *Citizen) relationship(other *Citizen) *Relationship {
+	if citizen.ledger == nil {
+		citizen.ledger = map[*Citizen]*Relationship{}
+	}
+	relationship := citizen.ledger[other]
+	if relationship == nil {
+		relationship = &Relationship{}
+		citizen.ledger[other] = relationship
+	}
+	return relationship
+}
+
+func (playground *Playground) rememberHelp(donor *Citizen, recipient *Citizen) {
+	donor.relationship(recipient).MyLastMove = MoveHelped
+	relationship := recipient.relationship(donor)
+	relationship.HelpedMe++
+	relationship.TheirLastMove = MoveHelped
+}
+
+func (playground *Playground) rememberRefusal(refuser *Citizen, refused *Citizen) {
+	refuser.relationship(refused).MyLastMove = MoveRefused
+	relationship := refused.relationship(refuser)
+	relationship.RefusedMe++
+	relationship.TheirLastMove = MoveRefused
+}
+
+// forget removes the dead citizen from the memory of everybody else.
+func (playground *Playground) forget(deadCitizen *Citizen) {
+	for _, citizen := range playground.Citizens {
+		delete(citizen.ledger, deadCitizen)
+	}
+}
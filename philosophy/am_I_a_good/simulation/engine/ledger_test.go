@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"testing"
+)
+
+func TestPrivateMemory(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		cfg := DefaultConfig()
+		cfg.EnablePrivateMemory = enabled
+		playground := NewPlayground(cfg, 1)
+		strategy := &scriptedStrategy{}
+		playground.AddCitizens(strategy, 3)
+		citizen, helped, refused := playground.Citizens[0], playground.Citizens[1], playground.Citizens[2]
+		citizen.HasEnergy = cfg.RequiredEnergy
+		helped.HasEnergy, refused.HasEnergy = 0, 0
+		strategy.actions = func(observation *Observation, food Food) []Action {
+			observation.HungryPeople()
+			return []Action{
+				{ActionType: ActionTypeEat, Amount: food.Amount - 500, Destination: citizen.id},
+				{ActionType: ActionTypeEat, Amount: 500, Destination: helped.id},
+			}
+		}
+
+		// the help is not enough to save anybody, so the citizen
+		// is greedy and refuses all the hungry it did not help
+		playground.handleFood(citizen, &Food{Amount: 2000})
+
+		expected := map[*Citizen]Relationship{}
+		if enabled {
+			expected[helped] = Relationship{HelpedMe: 1, TheirLastMove: MoveHelped}
+			expected[refused] = Relationship{RefusedMe: 1, TheirLastMove: MoveRefused}
+		}
+		for other, relationship := range expected {
+			if actual := other.Relationship(citizen); actual != relationship {
+				t.Errorf("enabled %v: remembers %+v, expected %+v", enabled, actual, relationship)
+			}
+		}
+		if !enabled {
+			continue
+		}
+		if citizen.Relationship(helped).MyLastMove != MoveHelped || citizen.Relationship(refused).MyLastMove != MoveRefused {
+			t.Errorf("the citizen remembers its own moves wrong: %+v, %+v",
+				citizen.Relationship(helped), citizen.Relationship(refused))
+		}
+
+		playground.RemoveCitizen(refused)
+		if _, ok := citizen.ledger[refused]; ok {
+			t.Error("the dead citizen is not forgotten")
+		}
+	}
+}
+
+func TestPrivateMemoryOfGenerousCitizen(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EnablePrivateMemory = true
+	playground := NewPlayground(cfg, 1)
+	strategy := &scriptedStrategy{}
+	playground.AddCitizens(strategy, 2)
+	citizen, other := playground.Citizens[0], playground.Citizens[1]
+	citizen.HasEnergy, other.HasEnergy = 0, 0
+	strategy.actions = func(_ *Observation, food Food) []Action {
+		return []Action{{ActionType: ActionTypeEat, Amount: food.Amount, Destination: citizen.id}}
+	}
+
+	// the portion is not enough to share
+	playground.handleFood(citizen, &Food{Amount: cfg.RequiredEnergy})
+
+	if relationship := other.Relationship(citizen); relationship != (Relationship{}) {
+		t.Errorf("a citizen who had nothing to share is remembered as %+v", relationship)
+	}
+}
@@ -0,0 +1,137 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+// Debt is an obligation created by ActionTypeLend (see Config.EnableLending).
+type Debt struct {
+	Creditor *Citizen
+	Debtor   *Citizen
+
+	// Principal is the amount of food lent.
+	Principal uint
+
+	// Owed is the amount of food still to be repaid (including
+	// the interest).
+	Owed uint
+
+	IssuedAtWeekID uint
+
+	// DueWeekID is the last week the debt may be repaid; after that
+	// the debtor defaults.
+	DueWeekID uint
+}
+
+// Debts returns what the citizen owes to others, the oldest first.
+func (citizen *Citizen) Debts() []Debt {
+	result := make([]Debt, 0, len(citizen.debts))
+	for _, debt := range citizen.debts {
+		result = append(result, *debt)
+	}
+	return result
+}
+
+// Loans returns what others owe to the citizen, the oldest first.
+func (citizen *Citizen) Loans() []Debt {
+	result := make([]Debt, 0, len(citizen.loans))
+	for _, debt := range citizen.loans {
+		result = append(result, *debt)
+	}
+	return result
+}
+
+// DebtTo returns the total amount the citizen owes to the creditor.
+func (citizen *Citizen) DebtTo(creditor *Citizen) uint {
+	result := uint(0)
+	for _, debt := range citizen.debts {
+		if debt.Creditor == creditor {
+			result += debt.Owed
+		}
+	}
+	return result
+}
+
+func (playground *Playground) lend(creditor *Citizen, recipient *Person, amount uint) {
+	cfg := &playground.Config
+	debtor := recipient.Citizen
+	if debtor == creditor {
+		panic(fmt.Sprintf("lending to itself: %#+v (%T)", creditor, creditor.Strategy))
+	}
+	debt := &Debt{
+		Creditor:       creditor,
+		Debtor:         debtor,
+		Principal:      amount,
+		Owed:           amount + uint(math.Ceil(float64(amount)*cfg.LendingInterestRate)),
+		IssuedAtWeekID: playground.weekID,
+		DueWeekID:      playground.weekID + cfg.LendingTermInWeeks,
+	}
+	creditor.loans = append(creditor.loans, debt)
+	debtor.debts = append(debtor.debts, debt)
+	recipient.HadEat += amount
+	playground.weekStats.LoansIssued++
+}
+
+func (playground *Playground) repay(debtor *Citizen, creditor *Person, amount uint) {
+	if amount > debtor.DebtTo(creditor.Citizen) {
+		panic(fmt.Sprintf("overpaying: %d > %d (%T)", amount, debtor.DebtTo(creditor.Citizen), debtor.Strategy))
+	}
+	creditor.HadEat += amount
+	for _, debt := range append([]*Debt{}, debtor.debts...) {
+		if amount == 0 {
+			break
+		}
+		if debt.Creditor != creditor.Citizen {
+			continue
+		}
+		repaid := debt.Owed
+		if repaid > amount {
+			repaid = amount
+		}
+		debt.Owed -= repaid
+		amount -= repaid
+		if debt.Owed == 0 {
+			removeDebt(debt)
+			debtor.RepaidDebts++
+			playground.weekStats.LoansRepaid++
+		}
+	}
+}
+
+// collectDebts makes the debtors who missed the due week default.
+func (playground *Playground) collectDebts() {
+	for _, citizen := range playground.Citizens {
+		for _, debt := range append([]*Debt{}, citizen.debts...) {
+			if debt.DueWeekID >= playground.weekID {
+				continue
+			}
+			removeDebt(debt)
+			citizen.Defaults++
+			citizen.SpottedAsDefaulter = true
+			playground.weekStats.LoanDefaults++
+		}
+	}
+}
+
+// writeOffDebts drops the debts of and to the dead citizen.
+func (playground *Playground) writeOffDebts(deadCitizen *Citizen) {
+	for _, debt := range append(append([]*Debt{}, deadCitizen.debts...), deadCitizen.loans...) {
+		removeDebt(debt)
+	}
+}
+
+func removeDebt(debt *Debt) {
+	debt.Debtor.debts = removeDebtFrom(debt.Debtor.debts, debt)
+	debt.Creditor.loans = removeDebtFrom(debt.Creditor.loans, debt)
+}
+
+// removeDebtFrom removes the debt preserving the order.
+func removeDebtFrom(debts []*Debt, removeDebt *Debt) []*Debt {
+	for debtIdx, debt := range debts {
+		if debt == removeDebt {
+			return append(debts[:debtIdx], debts[debtIdx+1:]...)
+		}
+	}
+	return debts
+}
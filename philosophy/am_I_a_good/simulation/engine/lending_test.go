@@ -0,0 +1,183 @@
+package engine
+
+import (
+	"testing"
+)
+
+func lendingPlayground(citizens uint) *Playground {
+	cfg := DefaultConfig()
+	cfg.EnableLending = true
+	cfg.LendingInterestRate = 0.1
+	cfg.LendingTermInWeeks = 2
+	playground := NewPlayground(cfg, 1)
+	playground.AddCitizens(&scriptedStrategy{}, citizens)
+	return playground
+}
+
+func TestLendAndRepay(t *testing.T) {
+	playground := lendingPlayground(2)
+	creditor, debtor := playground.Citizens[0], playground.Citizens[1]
+
+	playground.lend(creditor, &debtor.Person, 1000)
+	playground.weekID++
+	playground.lend(creditor, &debtor.Person, 15)
+
+	if debtor.HadEat != 1015 {
+		t.Errorf("the debtor has eaten %d instead of 1015", debtor.HadEat)
+	}
+	if owed := debtor.DebtTo(creditor); owed != 1100+17 {
+		t.Errorf("owes %d, expected %d (the interest is rounded up)", owed, 1100+17)
+	}
+	if debts, loans := debtor.Debts(), creditor.Loans(); len(debts) != 2 || len(loans) != 2 || debts[0] != loans[0] ||
+		debts[0].Principal != 1000 || debts[0].IssuedAtWeekID != 0 || debts[0].DueWeekID != 2 {
+		t.Errorf("unexpected debts %+v and loans %+v", debts, loans)
+	}
+
+	// the oldest debt is repaid first
+	playground.repay(debtor, &creditor.Person, 1105)
+	if creditor.HadEat != 1105 {
+		t.Errorf("the creditor has eaten %d instead of 1105", creditor.HadEat)
+	}
+	if debts := debtor.Debts(); len(debts) != 1 || debts[0].Principal != 15 || debts[0].Owed != 12 {
+		t.Errorf("unexpected debts after a repayment: %+v", debts)
+	}
+	if debtor.RepaidDebts != 1 || playground.weekStats.LoansIssued != 2 || playground.weekStats.LoansRepaid != 1 {
+		t.Errorf("repaid %d, stats %+v", debtor.RepaidDebts, playground.weekStats)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic on overpaying")
+		}
+	}()
+	playground.repay(debtor, &creditor.Person, 13)
+}
+
+func TestLendingDefaults(t *testing.T) {
+	playground := lendingPlayground(3)
+	creditor, debtor, other := playground.Citizens[0], playground.Citizens[1], playground.Citizens[2]
+	playground.lend(creditor, &debtor.Person, 100)
+
+	for playground.weekID = 1; playground.weekID <= 2; playground.weekID++ {
+		playground.collectDebts()
+		if len(debtor.Debts()) != 1 || debtor.SpottedAsDefaulter {
+			t.Fatalf("the debt is defaulted before the due week %d: %+v", playground.weekID, debtor)
+		}
+	}
+	playground.collectDebts()
+	if len(debtor.Debts()) != 0 || len(creditor.Loans()) != 0 {
+		t.Errorf("the defaulted debt is not written off: %+v, %+v", debtor.Debts(), creditor.Loans())
+	}
+	if !debtor.SpottedAsDefaulter || debtor.Defaults != 1 || playground.weekStats.LoanDefaults != 1 {
+		t.Errorf("the default is not recorded: %+v, %+v", debtor, playground.weekStats)
+	}
+
+	// the debts of the dead are written off
+	playground.lend(creditor, &debtor.Person, 100)
+	playground.lend(other, &creditor.Person, 100)
+	playground.RemoveCitizen(creditor)
+	if len(debtor.Debts()) != 0 || len(other.Loans()) != 0 {
+		t.Errorf("the debts of the dead are not written off: %+v, %+v", debtor.Debts(), other.Loans())
+	}
+	if debtor.Defaults != 1 {
+		t.Errorf("a written off debt is counted as a default")
+	}
+}
+
+func TestHandleFoodLending(t *testing.T) {
+	const portion = 2000
+	for _, tc := range []struct {
+		name      string
+		disabled  bool
+		setup     func(playground *Playground, citizen, other *Citizen)
+		actions   func(citizen, other *Citizen) []Action
+		greedy    bool
+		refusal   bool
+		panicking bool
+	}{
+		{
+			name: "a loan which saves",
+			actions: func(citizen, other *Citizen) []Action {
+				return []Action{
+					{ActionType: ActionTypeEat, Amount: portion / 2, Destination: citizen.id},
+					{ActionType: ActionTypeLend, Amount: portion / 2, Destination: other.id},
+				}
+			},
+		},
+		{
+			name: "a loan which does not save",
+			actions: func(citizen, other *Citizen) []Action {
+				return []Action{
+					{ActionType: ActionTypeEat, Amount: portion - 100, Destination: citizen.id},
+					{ActionType: ActionTypeLend, Amount: 100, Destination: other.id},
+				}
+			},
+			greedy: true,
+		},
+		{
+			name: "a repayment to the hungry",
+			setup: func(playground *Playground, citizen, other *Citizen) {
+				playground.lend(other, &citizen.Person, portion/2)
+				citizen.HadEat = 0
+			},
+			actions: func(citizen, other *Citizen) []Action {
+				return []Action{
+					{ActionType: ActionTypeEat, Amount: portion - 500, Destination: citizen.id},
+					{ActionType: ActionTypeRepay, Amount: 500, Destination: other.id},
+				}
+			},
+			greedy:  true,
+			refusal: true,
+		},
+		{
+			name:     "disabled",
+			disabled: true,
+			actions: func(citizen, other *Citizen) []Action {
+				return []Action{
+					{ActionType: ActionTypeEat, Amount: portion / 2, Destination: citizen.id},
+					{ActionType: ActionTypeLend, Amount: portion / 2, Destination: other.id},
+				}
+			},
+			panicking: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			playground := lendingPlayground(2)
+			playground.Config.EnableLending = !tc.disabled
+			playground.Config.EnablePrivateMemory = true
+			citizen, other := playground.Citizens[0], playground.Citizens[1]
+			citizen.HasEnergy, other.HasEnergy = 0, 0
+			if tc.setup != nil {
+				tc.setup(playground, citizen, other)
+			}
+			citizen.Strategy.(*scriptedStrategy).actions = func(observation *Observation, _ Food) []Action {
+				observation.People()
+				return tc.actions(citizen, other)
+			}
+
+			var recovered any
+			func() {
+				defer func() { recovered = recover() }()
+				playground.handleFood(citizen, &Food{Amount: portion})
+			}()
+			if (recovered != nil) != tc.panicking {
+				t.Fatalf("panic: %v", recovered)
+			}
+			if tc.panicking {
+				return
+			}
+
+			// neither a loan nor a repayment is a gift
+			if citizen.SavedPeople != 0 || other.WasSavedTimes != 0 || other.Relationship(citizen).HelpedMe != 0 {
+				t.Errorf("counted as altruism: saved %d, was saved %d, remembered %+v",
+					citizen.SavedPeople, other.WasSavedTimes, other.Relationship(citizen))
+			}
+			if citizen.SpottedAsGreedyLastTime != tc.greedy {
+				t.Errorf("greedy: %v, expected %v", citizen.SpottedAsGreedyLastTime, tc.greedy)
+			}
+			if refused := other.Relationship(citizen).RefusedMe > 0; refused != tc.refusal {
+				t.Errorf("remembered as a refusal: %v, expected %v", refused, tc.refusal)
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// PersonID identifies a person on a playground.
+type PersonID uint64
+
+// StrategyID identifies the strategy a citizen follows without giving
+// access to it: the citizens following the same strategy instance have
+// equal StrategyIDs.
+type StrategyID struct {
+	strategy Strategy
+}
+
+// PlaygroundID identifies the playground of an observation without
+// giving access to it: the observations on the same playground have
+// equal PlaygroundIDs, and the playgrounds never share one even if
+// they have the same seed.
+type PlaygroundID struct {
+	playground *Playground
+}
+
+// CitizenView is a read-only view of what everybody knows about
+// a citizen: its public reputation. It reads the current state of
+// the citizen, which does not change while a strategy decides.
+type CitizenView struct {
+	citizen *Citizen
+}
+
+func newCitizenView(citizen *Citizen) CitizenView {
+	return CitizenView{citizen: citizen}
+}
+
+func (view CitizenView) ID() PersonID {
+	return view.citizen.id
+}
+
+func (view CitizenView) Strategy() StrategyID {
+	return StrategyID{view.citizen.Strategy}
+}
+
+func (view CitizenView) SpottedAsGreedyOnce() bool {
+	return view.citizen.SpottedAsGreedyOnce
+}
+
+func (view CitizenView) SpottedAsGreedyLastTime() bool {
+	return view.citizen.SpottedAsGreedyLastTime
+}
+
+func (view CitizenView) SpottedAsDefaulter() bool {
+	return view.citizen.SpottedAsDefaulter
+}
+
+func (view CitizenView) SpottedAsNonPunisherLastTime() bool {
+	return view.citizen.SpottedAsNonPunisherLastTime
+}
+
+func (view CitizenView) SpottedAsThief() bool {
+	return view.citizen.SpottedAsThief
+}
+
+func (view CitizenView) SpottedAsTaxEvader() bool {
+	return view.citizen.SpottedAsTaxEvader
+}
+
+func (view CitizenView) SavedPeople() uint {
+	return view.citizen.SavedPeople
+}
+
+func (view CitizenView) WasSavedTimes() uint {
+	return view.citizen.WasSavedTimes
+}
+
+func (view CitizenView) Defaults() uint {
+	return view.citizen.Defaults
+}
+
+// SavedPeopleInLastWeeks returns the amount of times the citizen saved
+// somebody during the last "weeks" weeks (including the current one).
+func (view CitizenView) SavedPeopleInLastWeeks(weeks uint) uint {
+	return view.citizen.SavedPeopleInLastWeeks(weeks)
+}
+
+// WasSavedTimesInLastWeeks returns the amount of times the citizen was
+// saved during the last "weeks" weeks (including the current one).
+func (view CitizenView) WasSavedTimesInLastWeeks(weeks uint) uint {
+	return view.citizen.WasSavedTimesInLastWeeks(weeks)
+}
+
+// CaughtStealingInLastWeeks returns the amount of times the citizen was
+// caught stealing during the last "weeks" weeks (including the current
+// one).
+func (view CitizenView) CaughtStealingInLastWeeks(weeks uint) uint {
+	return view.citizen.CaughtStealingInLastWeeks(weeks)
+}
+
+// PersonView is a read-only view of a person. It reads the current
+// state of the person, which does not change while a strategy decides,
+// but does change between the calls of the strategy.
+type PersonView struct {
+	person *Person
+}
+
+func newPersonView(person *Person) PersonView {
+	return PersonView{person: person}
+}
+
+func (view PersonView) ID() PersonID {
+	return view.person.id
+}
+
+func (view PersonView) IsChild() bool {
+	return view.person.IsChild()
+}
+
+func (view PersonView) AgeInWeeks() uint {
+	return view.person.AgeInWeeks
+}
+
+func (view PersonView) HasEnergy() uint {
+	return view.person.HasEnergy
+}
+
+func (view PersonView) HadEat() uint {
+	return view.person.HadEat
+}
+
+func (view PersonView) OwnsFood() uint {
+	return view.person.OwnsFood
+}
+
+func (view PersonView) TotalEnergy() uint {
+	return view.person.TotalEnergy()
+}
+
+// Citizen returns the person itself or, for a child, its parent.
+func (view PersonView) Citizen() CitizenView {
+	return newCitizenView(view.person.Citizen)
+}
+
+// DebtView is a read-only snapshot of a debt of the citizen
+// (see Config.EnableLending).
+type DebtView struct {
+	Creditor  PersonID
+	Owed      uint
+	DueWeekID uint
+}
+
+// Observation is what a citizen knows when its strategy decides.
+//
+// It and its views are read-only and valid only during the call it is
+// passed to: the views read the live state of the playground instead
+// of copying it, so they should not be kept for later. Strategies
+// refer to other people by their PersonID, and the engine accepts only
+// the people the observation showed to the strategy (the citizen
+// itself, its children and the people returned by the methods of
+// the observation), so the engine is the only one changing
+// the playground.
+type Observation struct {
+	Self     PersonView
+	Children []PersonView
+	WeekID   uint
+
+	// Playground identifies the playground the citizen lives on.
+	Playground PlaygroundID
+
+	// StrategyState is the state of the citizen (see StatefulStrategy).
+	StrategyState any
+
+	// id marks the people shown by the observation (see Person.observedBy).
+	id             uint64
+	shownRelatives bool
+	playground     *Playground
+	citizen        *Citizen
+}
+
+func (playground *Playground) observe(citizen *Citizen) *Observation {
+	playground.lastObservationID++
+	observation := &Observation{
+		Self:          newPersonView(&citizen.Person),
+		WeekID:        playground.weekID,
+		Playground:    PlaygroundID{playground},
+		StrategyState: citizen.StrategyState,
+		id:            playground.lastObservationID,
+		playground:    playground,
+		citizen:       citizen,
+	}
+	if len(citizen.Children) > 0 {
+		observation.Children = make([]PersonView, 0, len(citizen.Children))
+		for _, child := range citizen.Children {
+			observation.Children = append(observation.Children, observation.show(&child.Person))
+		}
+	}
+	return observation
+}
+
+// Observe returns what the citizen knows now, the same as its strategy
+// gets when deciding (e.g. to call the strategy outside of the weeks).
+func (playground *Playground) Observe(citizen *Citizen) *Observation {
+	return playground.observe(citizen)
+}
+
+// Config returns the rules of the world.
+func (observation *Observation) Config() Config {
+	return observation.playground.Config
+}
+
+// RandUintn returns a random number in [0, n), or zero if n is zero.
+func (observation *Observation) RandUintn(n uint) uint {
+	return observation.playground.RandUintn(n)
+}
+
+// RandFloat64 returns a random number in [0, 1).
+func (observation *Observation) RandFloat64() float64 {
+	return observation.playground.Rand.Float64()
+}
+
+// RandPerm returns a random permutation of [0, n).
+func (observation *Observation) RandPerm(n int) []int {
+	return observation.playground.Rand.Perm(n)
+}
+
+// RandShuffle shuffles n elements using the swap function.
+func (observation *Observation) RandShuffle(n int, swap func(i, j int)) {
+	observation.playground.Rand.Shuffle(n, swap)
+}
+
+// show marks the person as observed and returns its view.
+func (observation *Observation) show(person *Person) PersonView {
+	person.observedBy = observation.id
+	return newPersonView(person)
+}
+
+func (observation *Observation) showAll(people []*Person) []PersonView {
+	result := make([]PersonView, 0, len(people))
+	for _, person := range people {
+		result = append(result, observation.show(person))
+	}
+	return result
+}
+
+// People returns everybody: the citizens and their children.
+func (observation *Observation) People() []PersonView {
+	return observation.showAll(observation.playground.People())
+}
+
+// HungryPeople returns the people (including the citizen itself and its
+// children) who do not have enough energy to survive the week.
+func (observation *Observation) HungryPeople() []PersonView {
+	requiredEnergy := observation.playground.Config.RequiredEnergy
+	var result []PersonView
+	for _, person := range observation.playground.People() {
+		if person.TotalEnergy() < requiredEnergy {
+			result = append(result, observation.show(person))
+		}
+	}
+	return result
+}
+
+// Citizens returns all the adult citizens.
+func (observation *Observation) Citizens() []PersonView {
+	result := make([]PersonView, 0, len(observation.playground.Citizens))
+	for _, citizen := range observation.playground.Citizens {
+		result = append(result, observation.show(&citizen.Person))
+	}
+	return result
+}
+
+// Relatives returns the citizens of the family of the citizen
+// (including the citizen itself unless it is dead).
+func (observation *Observation) Relatives() []PersonView {
+	family := observation.citizen.Family
+	if family == nil {
+		return nil
+	}
+	// the relatives are not marked one by one (see isRelative): families
+	// may be large and are shown on every food portion
+	observation.shownRelatives = true
+	result := make([]PersonView, 0, len(family.Citizens))
+	for _, citizen := range family.Citizens {
+		result = append(result, newPersonView(&citizen.Person))
+	}
+	return result
+}
+
+// Debts returns the debts of the citizen, the oldest first
+// (see Config.EnableLending).
+func (observation *Observation) Debts() []DebtView {
+	var result []DebtView
+	for _, debt := range observation.citizen.Debts() {
+		observation.show(&debt.Creditor.Person)
+		result = append(result, DebtView{
+			Creditor:  debt.Creditor.id,
+			Owed:      debt.Owed,
+			DueWeekID: debt.DueWeekID,
+		})
+	}
+	return result
+}
+
+// Guard returns how much food the citizen spent on guarding its hidden
+// food since the last theft phase (see Config.EnableTheft).
+func (observation *Observation) Guard() uint {
+	return observation.citizen.Guard()
+}
+
+// Relationship returns what the citizen remembers about the other
+// citizen (see Config.EnablePrivateMemory).
+func (observation *Observation) Relationship(other CitizenView) Relationship {
+	return observation.citizen.Relationship(other.citizen)
+}
+
+// Reputation returns what the citizen heard about the other citizen
+// through gossip (see Config.EnableGossip).
+func (observation *Observation) Reputation(other CitizenView) Reputation {
+	return observation.citizen.Reputation(other.citizen)
+}
+
+// person returns the person with the given ID if the observation showed
+// it to the strategy, and panics otherwise.
+func (observation *Observation) person(id PersonID) *Person {
+	citizen := observation.citizen
+	if id == citizen.id {
+		return &citizen.Person
+	}
+	person := observation.playground.persons[id]
+	if person == nil || (person.observedBy != observation.id && !observation.isRelative(person)) {
+		panic(fmt.Sprintf("cheater! person #%d was not observed by %T", id, citizen.Strategy))
+	}
+	return person
+}
+
+func (observation *Observation) isRelative(person *Person) bool {
+	return observation.shownRelatives && !person.IsChild() &&
+		person.Citizen.Family != nil && person.Citizen.Family == observation.citizen.Family
+}
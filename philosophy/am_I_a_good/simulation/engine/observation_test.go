@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// expectCheater checks that resolving the ID panics as a cheat.
+func expectCheater(t *testing.T, observation *Observation, id PersonID) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "was not observed") {
+			t.Errorf("person #%d: expected a panic about an unobserved person, got: %v", id, r)
+		}
+	}()
+	observation.person(id)
+}
+
+func TestObservationPerson(t *testing.T) {
+	playground := NewPlayground(DefaultConfig(), 1)
+	playground.AddCitizens(&scriptedStrategy{}, 2)
+	citizen, other := playground.Citizens[0], playground.Citizens[1]
+	citizen.HasEnergy = playground.Config.CreateBabyEnergy
+	citizen.CreateBaby()
+	child := citizen.Children[0]
+
+	observation := playground.observe(citizen)
+	if observation.person(citizen.id) != &citizen.Person || observation.person(child.id) != &child.Person {
+		t.Errorf("the citizen and its children are not observed")
+	}
+	expectCheater(t, observation, other.id)
+	expectCheater(t, observation, other.id+100)
+
+	observation.Citizens()
+	if observation.person(other.id) != &other.Person {
+		t.Errorf("a shown citizen is not resolved")
+	}
+
+	// a person is shown only for the observation which showed it
+	expectCheater(t, playground.observe(citizen), other.id)
+
+	// the dead cannot be referred to
+	observation = playground.observe(citizen)
+	observation.People()
+	playground.RemoveCitizen(other)
+	expectCheater(t, observation, other.id)
+}
+
+func TestObservationRelatives(t *testing.T) {
+	playground := NewPlayground(DefaultConfig(), 1)
+	family := playground.AddCitizens(&scriptedStrategy{}, 3)
+	playground.AddCitizens(&scriptedStrategy{}, 1)
+	citizen, relative, stranger := playground.Citizens[0], playground.Citizens[1], playground.Citizens[3]
+	relative.HasEnergy = playground.Config.CreateBabyEnergy
+	relative.CreateBaby()
+
+	observation := playground.observe(citizen)
+	expectCheater(t, observation, relative.id)
+	relatives := observation.Relatives()
+	if len(relatives) != 3 {
+		t.Fatalf("%d relatives instead of 3", len(relatives))
+	}
+	if observation.person(relative.id) != &relative.Person {
+		t.Errorf("a shown relative is not resolved")
+	}
+	expectCheater(t, observation, relative.Children[0].id)
+	expectCheater(t, observation, stranger.id)
+
+	// the result is owned by the strategy
+	relatives[0] = relatives[2]
+	if family.Citizens[0] != citizen {
+		t.Errorf("changing the relatives changed the family")
+	}
+	if relatives := playground.observe(citizen).Relatives(); relatives[0].ID() != citizen.id {
+		t.Errorf("changing the relatives changed the next observation")
+	}
+}
+
+func TestObservationViews(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EnableTheft = true
+	playground := NewPlayground(cfg, 1)
+	playground.AddCitizens(&scriptedStrategy{}, 2)
+	citizen, other := playground.Citizens[0], playground.Citizens[1]
+	citizen.HasEnergy, other.HasEnergy = cfg.RequiredEnergy, 0
+	other.SpottedAsGreedyLastTime = true
+	other.theftLog.add(playground.weekID)
+
+	observation := playground.observe(citizen)
+	hungry := observation.HungryPeople()
+	if len(hungry) != 1 || hungry[0].ID() != other.id {
+		t.Fatalf("unexpected hungry people: %+v", hungry)
+	}
+	view := hungry[0].Citizen()
+	if !view.SpottedAsGreedyLastTime() || view.CaughtStealingInLastWeeks(1) != 1 || view.Strategy() != (StrategyID{other.Strategy}) {
+		t.Errorf("the view does not show the reputation")
+	}
+
+	// the views read the live state, so they are not to be kept
+	other.HasEnergy = 100
+	if hungry[0].HasEnergy() != 100 {
+		t.Errorf("the view shows %d energy instead of 100", hungry[0].HasEnergy())
+	}
+}
@@ -0,0 +1,162 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// OrphanPolicy defines what happens to the children of a dead citizen.
+type OrphanPolicy uint
+
+const (
+	// OrphanPolicyNone lets the orphans die with their parent.
+	OrphanPolicyNone = OrphanPolicy(iota)
+
+	// OrphanPolicyRelatives gives the orphans to a random citizen of
+	// the parent's family.
+	OrphanPolicyRelatives
+
+	// OrphanPolicyAltruists gives the orphans to a random citizen whose
+	// strategy implements Adopter and agrees to adopt.
+	OrphanPolicyAltruists
+
+	// OrphanPolicySchool puts the orphans to the public school
+	// (see School).
+	OrphanPolicySchool
+)
+
+func (policy OrphanPolicy) String() string {
+	switch policy {
+	case OrphanPolicyNone:
+		return "none"
+	case OrphanPolicyRelatives:
+		return "relatives"
+	case OrphanPolicyAltruists:
+		return "altruists"
+	case OrphanPolicySchool:
+		return "school"
+	}
+	return "unknown"
+}
+
+// ParseOrphanPolicy parses the output of OrphanPolicy.String.
+func ParseOrphanPolicy(s string) (OrphanPolicy, error) {
+	for policy := OrphanPolicyNone; policy <= OrphanPolicySchool; policy++ {
+		if policy.String() == s {
+			return policy, nil
+		}
+	}
+	return OrphanPolicyNone, fmt.Errorf("unknown orphan policy '%s'", s)
+}
+
+func (policy OrphanPolicy) MarshalText() ([]byte, error) {
+	return []byte(policy.String()), nil
+}
+
+func (policy *OrphanPolicy) UnmarshalText(b []byte) error {
+	parsed, err := ParseOrphanPolicy(string(b))
+	if err != nil {
+		return err
+	}
+	*policy = parsed
+	return nil
+}
+
+// Adopter is an optional interface of a Strategy which is asked to
+// adopt orphans if Config.OrphanPolicy is OrphanPolicyAltruists.
+type Adopter interface {
+	WantsToAdopt(observation *Observation, orphan PersonView) bool
+}
+
+// School is the public pool of orphans fed with Config.SchoolPortions
+// food portions every week before the rest of the food is found.
+// Its children graduate with the strategy of their dead parent.
+type School struct {
+	Children []*Child
+}
+
+func (school *School) removeChild(child *Child) {
+	if school == nil {
+		return
+	}
+	for childIdx, childCmp := range school.Children {
+		if childCmp != child {
+			continue
+		}
+		school.Children[childIdx] = school.Children[len(school.Children)-1]
+		school.Children = school.Children[:len(school.Children)-1]
+		break
+	}
+}
+
+// handleOrphans takes care of the children of the dead citizen
+// according to Config.OrphanPolicy.
+func (playground *Playground) handleOrphans(deadCitizen *Citizen) {
+	orphans := deadCitizen.Children
+	deadCitizen.Children = nil
+	for _, orphan := range orphans {
+		if otherParent := orphan.OtherParent; otherParent != nil && !otherParent.isDead {
+			orphan.Parent = otherParent
+			orphan.Citizen = otherParent
+			orphan.OtherParent = nil
+			otherParent.Children = append(otherParent.Children, orphan)
+			continue
+		}
+		orphan.IsOrphan = true
+		playground.weekStats.Orphans++
+
+		var adopter *Citizen
+		switch playground.Config.OrphanPolicy {
+		case OrphanPolicyNone:
+		case OrphanPolicyRelatives:
+			if deadCitizen.Family != nil && len(deadCitizen.Family.Citizens) > 0 {
+				relatives := deadCitizen.Family.Citizens
+				adopter = relatives[playground.RandUintn(uint(len(relatives)))]
+			}
+		case OrphanPolicyAltruists:
+			for _, citizenIdx := range playground.Rand.Perm(len(playground.Citizens)) {
+				candidate := playground.Citizens[citizenIdx]
+				if altruist, ok := candidate.Strategy.(Adopter); ok && altruist.WantsToAdopt(playground.observe(candidate), newPersonView(&orphan.Person)) {
+					adopter = candidate
+					break
+				}
+			}
+		case OrphanPolicySchool:
+			playground.School.Children = append(playground.School.Children, orphan)
+			continue
+		default:
+			panic(fmt.Sprintf("unknown orphan policy: %v", playground.Config.OrphanPolicy))
+		}
+
+		if adopter == nil {
+			orphan.Die()
+			playground.weekStats.OrphansLost++
+			continue
+		}
+		orphan.Parent = adopter
+		orphan.Citizen = adopter
+		adopter.Children = append(adopter.Children, orphan)
+		playground.weekStats.Adoptions++
+	}
+}
+
+// feedSchool takes up to Config.SchoolPortions of the found food
+// portions and splits them equally between the school children.
+func (playground *Playground) feedSchool(foundFood []*Food) []*Food {
+	school := playground.School
+	if len(school.Children) == 0 {
+		return foundFood
+	}
+	portions := playground.Config.SchoolPortions
+	if portions > uint(len(foundFood)) {
+		portions = uint(len(foundFood))
+	}
+	amount := uint(0)
+	for _, food := range foundFood[:portions] {
+		amount += food.Amount
+	}
+	share := amount / uint(len(school.Children))
+	for _, child := range school.Children {
+		child.HadEat += share
+	}
+	return foundFood[portions:]
+}
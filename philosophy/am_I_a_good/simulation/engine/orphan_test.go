@@ -0,0 +1,147 @@
+package engine
+
+import (
+	"testing"
+)
+
+// scriptedAdopter adopts orphans if the test says so.
+type scriptedAdopter struct {
+	scriptedStrategy
+	adopt bool
+}
+
+func (strategy *scriptedAdopter) WantsToAdopt(*Observation, PersonView) bool {
+	return strategy.adopt
+}
+
+// orphanPlayground returns a playground with a parent of two children,
+// its relative and two unrelated citizens: one willing to adopt and
+// one not.
+func orphanPlayground(policy OrphanPolicy) *Playground {
+	cfg := DefaultConfig()
+	cfg.OrphanPolicy = policy
+	cfg.SchoolPortions = 2
+	playground := NewPlayground(cfg, 1)
+	playground.AddCitizens(&scriptedAdopter{}, 2)
+	playground.AddCitizens(&scriptedAdopter{adopt: true}, 1)
+	playground.AddCitizens(&scriptedAdopter{}, 1)
+	parent := playground.Citizens[0]
+	parent.HasEnergy = cfg.CreateBabyEnergy * 2
+	parent.CreateBaby()
+	parent.CreateBaby()
+	return playground
+}
+
+func TestHandleOrphans(t *testing.T) {
+	for _, tc := range []struct {
+		policy  OrphanPolicy
+		adopter int
+		lost    bool
+	}{
+		{policy: OrphanPolicyNone, adopter: -1, lost: true},
+		{policy: OrphanPolicyRelatives, adopter: 1},
+		{policy: OrphanPolicyAltruists, adopter: 2},
+		{policy: OrphanPolicySchool, adopter: -1},
+	} {
+		t.Run(tc.policy.String(), func(t *testing.T) {
+			playground := orphanPlayground(tc.policy)
+			parent := playground.Citizens[0]
+			orphans := append([]*Child{}, parent.Children...)
+			var adopter *Citizen
+			if tc.adopter >= 0 {
+				adopter = playground.Citizens[tc.adopter]
+			}
+
+			playground.RemoveCitizen(parent)
+			playground.deaths()
+			stats := playground.weekStats
+			if stats.Orphans != 2 || (stats.Adoptions == 2) != (adopter != nil) || (stats.OrphansLost == 2) != tc.lost {
+				t.Errorf("unexpected stats: %+v", stats)
+			}
+			if len(parent.Children) != 0 {
+				t.Errorf("the dead parent still has %d children", len(parent.Children))
+			}
+			for _, orphan := range orphans {
+				if !orphan.IsOrphan {
+					t.Errorf("the child is not marked as an orphan")
+				}
+				if adopter != nil && (orphan.Parent != adopter || orphan.Citizen != adopter) {
+					t.Errorf("the orphan is not adopted by citizen #%d", tc.adopter)
+				}
+			}
+			if adopter != nil && len(adopter.Children) != 2 {
+				t.Errorf("the adopter has %d children instead of 2", len(adopter.Children))
+			}
+			for _, citizen := range playground.Citizens {
+				if citizen != adopter && len(citizen.Children) != 0 {
+					t.Errorf("a citizen who did not adopt has %d children", len(citizen.Children))
+				}
+			}
+			people := len(playground.Citizens)
+			if adopter != nil {
+				people += len(orphans)
+			}
+			if len(playground.People()) != people {
+				t.Errorf("%d people instead of %d", len(playground.People()), people)
+			}
+			if tc.policy == OrphanPolicySchool && len(playground.School.Children) != 2 {
+				t.Errorf("the school has %d children instead of 2", len(playground.School.Children))
+			}
+
+			// the strategies may refer to the living people only
+			persons := len(playground.Citizens)
+			if !tc.lost {
+				persons += len(orphans)
+			}
+			if len(playground.persons) != persons {
+				t.Errorf("%d persons instead of %d", len(playground.persons), persons)
+			}
+			if playground.persons[parent.id] != nil {
+				t.Errorf("the dead parent is still a person")
+			}
+			for _, orphan := range orphans {
+				if (playground.persons[orphan.id] != nil) == tc.lost {
+					t.Errorf("the orphan is a person: %v, it is lost: %v", playground.persons[orphan.id] != nil, tc.lost)
+				}
+			}
+		})
+	}
+}
+
+func TestSchool(t *testing.T) {
+	playground := orphanPlayground(OrphanPolicySchool)
+	cfg := &playground.Config
+	parent := playground.Citizens[0]
+	playground.RemoveCitizen(parent)
+	playground.deaths()
+	school := playground.School
+	hungry, graduate := school.Children[0], school.Children[1]
+
+	// SchoolPortions of the found food are split between the children
+	foundFood := []*Food{{Amount: 1000}, {Amount: 1000}, {Amount: 1000}}
+	if left := playground.feedSchool(foundFood); len(left) != 1 {
+		t.Errorf("%d portions are left instead of 1", len(left))
+	}
+	if hungry.HadEat != 1000 || graduate.HadEat != 1000 {
+		t.Errorf("the children have eaten %d and %d instead of 1000", hungry.HadEat, graduate.HadEat)
+	}
+
+	// the children die of hunger as any other children
+	hungry.HadEat, hungry.HasEnergy = 0, 0
+	playground.childDyingFromHunger(hungry)
+	if len(school.Children) != 1 || playground.weekStats.OrphanDeathsOfHunger != 1 {
+		t.Errorf("the hungry orphan did not die: %d children, %+v", len(school.Children), playground.weekStats)
+	}
+
+	// and graduate with the strategy of their dead parent
+	graduate.AgeInWeeks = cfg.PersonGraduationInWeeks + 1
+	citizens := len(playground.Citizens)
+	playground.graduation()
+	if len(school.Children) != 0 || len(playground.Citizens) != citizens+1 || playground.weekStats.OrphanGraduations != 1 {
+		t.Fatalf("the orphan did not graduate: %d children, %d citizens, %+v",
+			len(school.Children), len(playground.Citizens), playground.weekStats)
+	}
+	if graduated := playground.Citizens[len(playground.Citizens)-1]; graduated.Strategy != parent.Strategy {
+		t.Errorf("graduated with %T instead of the strategy of the dead parent", graduated.Strategy)
+	}
+}
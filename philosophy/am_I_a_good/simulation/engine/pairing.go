@@ -0,0 +1,89 @@
+package engine
+
+// MateChooser is an optional interface of a Strategy which decides
+// whether the citizen agrees to make a baby with the candidate
+// (see Config.EnableTwoParentReproduction). Strategies which do not
+// implement it agree with anybody.
+type MateChooser interface {
+	AcceptsMate(observation *Observation, candidate CitizenView) bool
+}
+
+func (playground *Playground) acceptsMate(citizen *Citizen, candidate *Citizen) bool {
+	chooser, ok := citizen.Strategy.(MateChooser)
+	return !ok || chooser.AcceptsMate(playground.observe(citizen), newCitizenView(candidate))
+}
+
+// generateBabiesInPairs pairs up the citizens who have at least half of
+// StartBabyEnergy and agree with each other; each pair makes a baby
+// sharing CreateBabyEnergy equally.
+func (playground *Playground) generateBabiesInPairs() {
+	cfg := &playground.Config
+
+	var candidates []*Citizen
+	for _, citizen := range playground.Citizens {
+		if citizen.hadBaby && playground.weekID-citizen.lastBabyWeekID < 40 {
+			continue
+		}
+		if citizen.HasEnergy >= cfg.StartBabyEnergy/2 && citizen.HasEnergy >= cfg.CreateBabyEnergy/2 {
+			candidates = append(candidates, citizen)
+		}
+	}
+	playground.Rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	paired := make(map[*Citizen]bool, len(candidates))
+	for idx, citizen := range candidates {
+		if paired[citizen] {
+			continue
+		}
+		for _, candidate := range candidates[idx+1:] {
+			if paired[candidate] || !playground.acceptsMate(citizen, candidate) || !playground.acceptsMate(candidate, citizen) {
+				continue
+			}
+			paired[citizen] = true
+			paired[candidate] = true
+			citizen.createBabyWith(candidate)
+			playground.weekStats.Births++
+			if citizen.Strategy == candidate.Strategy {
+				playground.weekStats.SameStrategyBirths++
+			}
+			break
+		}
+	}
+}
+
+func (citizen *Citizen) createBabyWith(partner *Citizen) {
+	createBabyEnergy := citizen.Playground.Config.CreateBabyEnergy
+	citizen.HasEnergy -= createBabyEnergy / 2
+	partner.HasEnergy -= createBabyEnergy - createBabyEnergy/2
+	child := &Child{
+		Person: Person{
+			AgeInWeeks: 0,
+			Playground: citizen.Playground,
+			Citizen:    citizen,
+			HasEnergy:  createBabyEnergy / 2,
+		},
+		Parent:      citizen,
+		OtherParent: partner,
+	}
+	citizen.Playground.addPerson(&child.Person)
+	citizen.Children = append(citizen.Children, child)
+	for _, parent := range []*Citizen{citizen, partner} {
+		parent.hadBaby = true
+		parent.lastBabyWeekID = citizen.Playground.weekID
+	}
+}
+
+// graduationParent returns the parent whose strategy and family the child
+// takes at the graduation: a random one of the two if the child has two
+// parents.
+func (child *Child) graduationParent() *Citizen {
+	if child.OtherParent == nil || child.IsOrphan {
+		return child.Parent
+	}
+	if child.Playground.Rand.Intn(2) == 0 {
+		return child.OtherParent
+	}
+	return child.Parent
+}
@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"testing"
+)
+
+// scriptedMateChooser accepts the mates of the same group only.
+type scriptedMateChooser struct {
+	scriptedStrategy
+	group map[*Citizen]int
+}
+
+func (strategy *scriptedMateChooser) AcceptsMate(observation *Observation, candidate CitizenView) bool {
+	return strategy.group[observation.citizen] == strategy.group[candidate.citizen]
+}
+
+func pairingPlayground() (*Playground, *scriptedMateChooser) {
+	cfg := DefaultConfig()
+	cfg.EnableTwoParentReproduction = true
+	playground := NewPlayground(cfg, 1)
+	chooser := &scriptedMateChooser{group: map[*Citizen]int{}}
+	playground.AddCitizens(chooser, 4)
+	playground.AddCitizens(&scriptedStrategy{}, 1)
+	for idx, citizen := range playground.Citizens {
+		citizen.HasEnergy = cfg.StartBabyEnergy / 2
+		chooser.group[citizen] = idx % 2
+	}
+	return playground, chooser
+}
+
+func TestGenerateBabiesInPairs(t *testing.T) {
+	playground, _ := pairingPlayground()
+	cfg := &playground.Config
+	poor := playground.Citizens[4]
+	poor.HasEnergy = cfg.StartBabyEnergy/2 - 1
+
+	playground.generateBabiesInPairs()
+	if stats := playground.weekStats; stats.Births != 2 || stats.SameStrategyBirths != 2 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	for idx, citizen := range playground.Citizens[:4] {
+		if citizen.HasEnergy != cfg.StartBabyEnergy/2-cfg.CreateBabyEnergy/2 {
+			t.Errorf("citizen #%d has %d energy left", idx, citizen.HasEnergy)
+		}
+		for _, child := range citizen.Children {
+			partner := child.OtherParent
+			if partner == nil || partner == citizen || child.Parent != citizen {
+				t.Fatalf("unexpected parents: %p and %p", child.Parent, partner)
+			}
+			if (partner == playground.Citizens[0] || partner == playground.Citizens[2]) != (idx%2 == 0) {
+				t.Errorf("citizen #%d made a baby with a partner of another group", idx)
+			}
+		}
+	}
+	if poor.HasEnergy != cfg.StartBabyEnergy/2-1 || len(poor.Children) != 0 {
+		t.Errorf("the poor citizen made a baby")
+	}
+
+	// the parents rest for a while before the next baby
+	for _, citizen := range playground.Citizens {
+		citizen.HasEnergy = cfg.StartBabyEnergy
+	}
+	playground.weekStats = WeekStats{}
+	playground.generateBabiesInPairs()
+	if playground.weekStats.Births != 0 {
+		t.Errorf("%d births right after the previous ones", playground.weekStats.Births)
+	}
+}
+
+func TestTwoParentsChildren(t *testing.T) {
+	playground, _ := pairingPlayground()
+	parent, partner := playground.Citizens[0], playground.Citizens[2]
+	parent.createBabyWith(partner)
+	child := parent.Children[0]
+
+	// the child has a random parent's strategy unless it is an orphan
+	seen := map[*Citizen]bool{}
+	for try := 0; try < 100; try++ {
+		seen[child.graduationParent()] = true
+	}
+	if !seen[parent] || !seen[partner] || len(seen) != 2 {
+		t.Errorf("unexpected graduation parents: %v", seen)
+	}
+
+	// the other parent takes the child
+	playground.RemoveCitizen(parent)
+	playground.deaths()
+	if child.IsOrphan || child.Parent != partner || child.Citizen != partner || child.OtherParent != nil ||
+		len(partner.Children) != 1 || playground.weekStats.Orphans != 0 {
+		t.Fatalf("the child is not taken by the other parent: %+v", child)
+	}
+
+	// and the child is an orphan only when both are dead
+	playground.RemoveCitizen(partner)
+	playground.deaths()
+	if !child.IsOrphan || playground.weekStats.Orphans != 1 {
+		t.Errorf("the child of two dead parents is not an orphan")
+	}
+	if child.graduationParent() != partner {
+		t.Errorf("the orphan graduates with the strategy of a parent other than the last one")
+	}
+}
@@ -0,0 +1,200 @@
+package engine
+
+type Person struct {
+	AgeInWeeks uint
+	HadEat     uint
+	HasEnergy  uint
+	Playground *Playground
+	OwnsFood   uint
+	Citizen    *Citizen
+
+	id      PersonID
+	stashes []uint
+	guard   uint
+
+	// observedBy is the ID of the last observation which showed
+	// the person to a strategy (see Observation.person).
+	observedBy uint64
+}
+
+// ID returns the identifier the strategies refer to the person by.
+func (person *Person) ID() PersonID {
+	return person.id
+}
+
+func (person *Person) EatEnergy() uint {
+	cfg := &person.Playground.Config
+	hadEat := person.HadEat
+	if hadEat <= cfg.RequiredEnergy || cfg.ExtraFoodEfficiency == 1 {
+		return hadEat
+	}
+	return uint(float64(cfg.RequiredEnergy) + float64(hadEat-cfg.RequiredEnergy)*cfg.ExtraFoodEfficiency)
+}
+
+func (person *Person) TotalEnergy() uint {
+	return person.HasEnergy + person.OwnsFood + person.EatEnergy()
+}
+
+// IsChild returns true if the person is not a citizen, yet.
+func (person *Person) IsChild() bool {
+	return &person.Citizen.Person != person
+}
+
+type Child struct {
+	Person
+
+	// Parent is the citizen raising the child: the biological one or
+	// the adopter; for a school child it is the dead parent.
+	Parent *Citizen
+
+	// OtherParent is the second parent, if the child was made by a pair
+	// (see Config.EnableTwoParentReproduction); it takes the child if
+	// Parent dies.
+	OtherParent *Citizen
+
+	// IsOrphan is true if the biological parents died before
+	// the graduation (see Config.OrphanPolicy).
+	IsOrphan bool
+
+	lessons []lesson
+}
+
+func (child *Child) Die() {
+	child.Parent.removeChild(child)
+	child.Playground.School.removeChild(child)
+	child.Playground.removePerson(&child.Person)
+}
+
+func (child *Child) Graduate() {
+	child.Parent.removeChild(child)
+	child.Playground.School.removeChild(child)
+	child.Playground.removePerson(&child.Person)
+	parent := child.graduationParent()
+	strategy, channel := parent.Strategy, LearningChannelParent
+	if child.Playground.Config.EnableChildhoodLearning {
+		strategy, channel = child.learnedStrategy()
+	}
+	strategy = child.Playground.transmit(nil, strategy)
+	child.Playground.cultureStatsOf(strategy).Learned[channel]++
+	graduate := child.Playground.addCitizen(strategy, child.AgeInWeeks, parent.Family)
+	if child.Playground.Config.EnableGossip && !parent.isDead {
+		child.Playground.linkRelatives(parent, graduate)
+	}
+}
+
+type Citizen struct {
+	Person
+	Family                    *Family
+	Children                  []*Child
+	Strategy                  Strategy
+	SpottedAsGreedyOnce       bool
+	SpottedAsGreedyLastTime   bool
+	SavedPeople               uint
+	WasSavedTimes             uint
+	ChangeStrategyProbability float64
+
+	// SpottedAsDefaulter is true if the citizen ever failed to repay
+	// a debt in time (see Config.EnableLending).
+	SpottedAsDefaulter bool
+	Defaults           uint
+	RepaidDebts        uint
+
+	// SpottedAsNonPunisherLastTime is true if the citizen did not punish
+	// anybody the last time it had surplus food while somebody was
+	// spotted as greedy (see Config.EnablePunishment).
+	SpottedAsNonPunisherLastTime bool
+	Punished                     uint
+	WasPunishedTimes             uint
+
+	// SpottedAsThief is true if the citizen was ever caught stealing
+	// (see Config.EnableTheft); being caught also makes the citizen
+	// spotted as greedy.
+	SpottedAsThief bool
+	TheftAttempts  uint
+
+	// SpottedAsTaxEvader is true if the citizen was ever caught by
+	// an audit (see Config.EnableInstitution).
+	SpottedAsTaxEvader bool
+
+	// StrategyState is the internal state of the Strategy for this
+	// citizen (see StatefulStrategy).
+	StrategyState any
+
+	savedPeopleLog   eventLog
+	wasSavedTimesLog eventLog
+	theftLog         eventLog
+	ledger           map[*Citizen]*Relationship
+	gossip           gossipState
+	debts            []*Debt
+	loans            []*Debt
+	isDead           bool
+	hadBaby          bool
+	lastBabyWeekID   uint
+}
+
+// SavedPeopleInLastWeeks returns the amount of times the citizen saved
+// somebody during the last "weeks" weeks (including the current one).
+func (citizen *Citizen) SavedPeopleInLastWeeks(weeks uint) uint {
+	return citizen.savedPeopleLog.countSince(citizen.Playground.sinceWeekID(weeks))
+}
+
+// WasSavedTimesInLastWeeks returns the amount of times the citizen was
+// saved during the last "weeks" weeks (including the current one).
+func (citizen *Citizen) WasSavedTimesInLastWeeks(weeks uint) uint {
+	return citizen.wasSavedTimesLog.countSince(citizen.Playground.sinceWeekID(weeks))
+}
+
+// CaughtStealingInLastWeeks returns the amount of times the citizen was
+// caught stealing during the last "weeks" weeks (including the current
+// one, see Config.EnableTheft).
+func (citizen *Citizen) CaughtStealingInLastWeeks(weeks uint) uint {
+	return citizen.theftLog.countSince(citizen.Playground.sinceWeekID(weeks))
+}
+
+func (citizen *Citizen) removeChild(child *Child) {
+	for childIdx, childCmp := range citizen.Children {
+		if childCmp != child {
+			continue
+		}
+		citizen.Children[childIdx] = citizen.Children[len(citizen.Children)-1]
+		citizen.Children = citizen.Children[:len(citizen.Children)-1]
+		break
+	}
+}
+
+func (citizen *Citizen) CreateBaby() {
+	createBabyEnergy := citizen.Playground.Config.CreateBabyEnergy
+	citizen.HasEnergy -= createBabyEnergy
+	child := &Child{
+		Person: Person{
+			AgeInWeeks: 0,
+			Playground: citizen.Playground,
+			Citizen:    citizen,
+			HasEnergy:  createBabyEnergy / 2,
+		},
+		Parent: citizen,
+	}
+	citizen.Playground.addPerson(&child.Person)
+	citizen.Children = append(citizen.Children, child)
+}
+
+// Family is a group of citizens descending from the same initial
+// group of citizens added by Playground.AddCitizens.
+type Family struct {
+	Citizens []*Citizen
+}
+
+func (family *Family) addCitizen(citizen *Citizen) {
+	family.Citizens = append(family.Citizens, citizen)
+}
+
+func (family *Family) removeCitizen(removeCitizen *Citizen) {
+	for citizenIdx, citizen := range family.Citizens {
+		if citizen != removeCitizen {
+			continue
+		}
+		family.Citizens[citizenIdx] = family.Citizens[len(family.Citizens)-1]
+		family.Citizens = family.Citizens[:len(family.Citizens)-1]
+		break
+	}
+}
@@ -0,0 +1,596 @@
+package engine
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+type Playground struct {
+	Config Config
+
+	// Rand is the only source of randomness of the playground (and of
+	// the strategies played on it), so that a playground is reproducible
+	// given its seed.
+	Rand *rand.Rand
+	Seed int64
+
+	// Institution is nil unless Config.EnableInstitution is set.
+	Institution *Institution
+
+	// School is nil unless Config.OrphanPolicy is OrphanPolicySchool.
+	School *School
+
+	Citizens     []*Citizen
+	weekID       uint
+	weekStats    WeekStats
+	totalStats   WeekStats
+	dead         []*Citizen
+	cultureStats map[Strategy]*CultureStats
+	genotypes    map[string]Genotype
+	lastRumorID  uint64
+	lastPersonID PersonID
+
+	// persons are the living people by their IDs.
+	persons           map[PersonID]*Person
+	lastObservationID uint64
+
+	peopleCacheWeekID uint
+	peopleCache       []*Person
+
+	// hungryCitizens caches HungryCitizens during a round of the food
+	// distribution: the citizens only gain energy then (except for
+	// punishments, which invalidate the cache), so the fed ones are
+	// just dropped from it.
+	hungryCitizens      []*Citizen
+	hungryCitizensValid bool
+}
+
+func NewPlayground(cfg Config, seed int64) *Playground {
+	playground := &Playground{
+		Config:  cfg,
+		Rand:    rand.New(rand.NewSource(seed)),
+		Seed:    seed,
+		persons: map[PersonID]*Person{},
+	}
+	if cfg.EnableInstitution {
+		playground.Institution = &Institution{}
+	}
+	if cfg.OrphanPolicy == OrphanPolicySchool {
+		playground.School = &School{}
+	}
+	return playground
+}
+
+func (playground *Playground) people() []*Person {
+	var result []*Person
+	for _, citizen := range playground.Citizens {
+		result = append(result, &citizen.Person)
+		for _, child := range citizen.Children {
+			result = append(result, &child.Person)
+		}
+	}
+	return result
+}
+
+func (playground *Playground) People() []*Person {
+	if playground.peopleCacheWeekID != playground.weekID || playground.peopleCache == nil {
+		playground.peopleCache = playground.people()
+		playground.peopleCacheWeekID = playground.weekID
+	}
+	return playground.peopleCache
+}
+
+// addPerson assigns a new ID to the person.
+func (playground *Playground) addPerson(person *Person) {
+	playground.lastPersonID++
+	person.id = playground.lastPersonID
+	playground.persons[person.id] = person
+}
+
+func (playground *Playground) removePerson(person *Person) {
+	delete(playground.persons, person.id)
+}
+
+// WeekID returns the amount of weeks iterated so far.
+func (playground *Playground) WeekID() uint {
+	return playground.weekID
+}
+
+// sinceWeekID returns the ID of the first week of the last "weeks" weeks.
+func (playground *Playground) sinceWeekID(weeks uint) uint {
+	if weeks > playground.weekID {
+		return 0
+	}
+	return playground.weekID - weeks + 1
+}
+
+// RandUintn returns a random number in [0, n), or zero if n is zero.
+func (playground *Playground) RandUintn(n uint) uint {
+	if n == 0 {
+		return 0
+	}
+	return uint(playground.Rand.Int63n(int64(n)))
+}
+
+// AddCitizens adds a new family of citizenAmount adult citizens
+// following the given strategy.
+func (playground *Playground) AddCitizens(strategy Strategy, citizenAmount uint) *Family {
+	cfg := &playground.Config
+	family := &Family{}
+	for i := uint(0); i < citizenAmount; i++ {
+		ageInWeeks := uint(0)
+		if cfg.EnableAging && cfg.PersonExpirationInWeeks > cfg.PersonGraduationInWeeks {
+			ageInWeeks = cfg.PersonGraduationInWeeks + playground.RandUintn(cfg.PersonExpirationInWeeks-cfg.PersonGraduationInWeeks)
+		}
+		playground.addCitizen(strategy, ageInWeeks, family)
+	}
+	return family
+}
+
+func (playground *Playground) AddCitizen(strategy Strategy, ageInWeeks uint) {
+	playground.addCitizen(strategy, ageInWeeks, &Family{})
+}
+
+func (playground *Playground) addCitizen(strategy Strategy, ageInWeeks uint, family *Family) *Citizen {
+	if genotype, ok := strategy.(Genotype); ok {
+		strategy = playground.internGenotype(genotype)
+	}
+	citizen := &Citizen{
+		Family:                    family,
+		ChangeStrategyProbability: playground.newChangeStrategyProbability(),
+	}
+	citizen.Person = Person{
+		AgeInWeeks: ageInWeeks,
+		Playground: playground,
+		Citizen:    citizen,
+	}
+	playground.addPerson(&citizen.Person)
+	playground.setStrategy(citizen, strategy)
+	playground.Citizens = append(playground.Citizens, citizen)
+	family.addCitizen(citizen)
+	return citizen
+}
+
+func (playground *Playground) newChangeStrategyProbability() float64 {
+	exponent := playground.Config.ChangeStrategyExponent
+	if exponent == 0 {
+		return 0
+	}
+	result := float64(1)
+	for i := uint(0); i < exponent; i++ {
+		result *= playground.Rand.Float64()
+	}
+	return result
+}
+
+// RemoveCitizen removes the dead citizen. Its estate and children
+// are handed over in the next death phase of IterateWeek.
+func (playground *Playground) RemoveCitizen(removeCitizen *Citizen) {
+	for citizenIdx, citizen := range playground.Citizens {
+		if citizen != removeCitizen {
+			continue
+		}
+
+		playground.Citizens[citizenIdx] = playground.Citizens[len(playground.Citizens)-1]
+		playground.Citizens = playground.Citizens[:len(playground.Citizens)-1]
+		break
+	}
+	if removeCitizen.Family != nil {
+		removeCitizen.Family.removeCitizen(removeCitizen)
+	}
+	removeCitizen.isDead = true
+	playground.removePerson(&removeCitizen.Person)
+	playground.finishStrategyState(removeCitizen, true)
+	playground.dead = append(playground.dead, removeCitizen)
+	if playground.Config.EnablePrivateMemory {
+		playground.forget(removeCitizen)
+	}
+	if playground.Config.EnableGossip {
+		playground.forgetGossip(removeCitizen)
+	}
+	if playground.Config.EnableLending {
+		playground.writeOffDebts(removeCitizen)
+	}
+}
+
+func (playground *Playground) HasHungryCitizens() bool {
+	requiredEnergy := playground.Config.RequiredEnergy
+	if !playground.hungryCitizensValid {
+		for _, citizen := range playground.Citizens {
+			if citizen.TotalEnergy() < requiredEnergy {
+				return true
+			}
+		}
+		return false
+	}
+	hungry := playground.hungryCitizens
+	for len(hungry) > 0 && hungry[0].TotalEnergy() >= requiredEnergy {
+		hungry = hungry[1:]
+	}
+	playground.hungryCitizens = hungry
+	return len(hungry) > 0
+}
+
+func (playground *Playground) HungryCitizens() []*Citizen {
+	requiredEnergy := playground.Config.RequiredEnergy
+	citizens := playground.Citizens
+	if playground.hungryCitizensValid {
+		citizens = playground.hungryCitizens
+	}
+	var result []*Citizen
+	for _, citizen := range citizens {
+		if citizen.TotalEnergy() < requiredEnergy {
+			result = append(result, citizen)
+		}
+	}
+	if playground.hungryCitizensValid {
+		playground.hungryCitizens = result
+	}
+	return result
+}
+
+func (playground *Playground) IterateWeek() {
+	playground.weekID++
+	playground.weekStats = WeekStats{}
+
+	if playground.Config.EnableTheft {
+		playground.thefts()
+	}
+	playground.distributeFood()
+	if playground.Institution != nil {
+		playground.redistribute()
+	}
+	playground.dyingFromHunger()
+	if playground.Config.EnableLending {
+		playground.collectDebts()
+	}
+	if playground.Config.EnableGossip {
+		playground.spreadGossip()
+	}
+	if playground.Config.EnableChildren {
+		if playground.Config.EnableTwoParentReproduction {
+			playground.generateBabiesInPairs()
+		} else {
+			playground.generateBabies()
+		}
+	}
+	if playground.Config.EnableAging {
+		playground.aging()
+	}
+	if playground.Config.EnableChildhoodLearning {
+		playground.childhoodLearning()
+	}
+	playground.deaths()
+	if playground.Config.EnableChildren {
+		playground.graduation()
+	}
+	playground.changeStrategies()
+	playground.totalStats.add(playground.weekStats)
+}
+
+func (playground *Playground) distributeFood() {
+	cfg := &playground.Config
+
+	var foundFood []*Food
+	for i := uint(0); i < cfg.AmountOfPortions; i++ {
+		foundFood = append(foundFood, &Food{Amount: cfg.PortionEnergy})
+	}
+	if playground.School != nil {
+		foundFood = playground.feedSchool(foundFood)
+	}
+
+	newCitizenFood := make([][]*Food, len(playground.Citizens))
+	for citizenIdx, citizen := range playground.Citizens {
+		if citizen.OwnsFood == 0 {
+			continue
+		}
+		newCitizenFood[citizenIdx] = append(newCitizenFood[citizenIdx], &Food{AlreadyHidden: true, Amount: citizen.takeAllHiddenFood()})
+	}
+
+	defer func() { playground.hungryCitizensValid = false }()
+	for len(foundFood) > 0 {
+		if len(playground.Citizens) == 0 {
+			return
+		}
+		for _, foodPortion := range foundFood {
+			citizenIdx := playground.RandUintn(uint(len(playground.Citizens)))
+			newCitizenFood[citizenIdx] = append(newCitizenFood[citizenIdx], foodPortion)
+		}
+		foundFood = foundFood[:0]
+
+		if cfg.ShuffleCitizens {
+			playground.Rand.Shuffle(len(playground.Citizens), func(i, j int) {
+				playground.Citizens[i], playground.Citizens[j] = playground.Citizens[j], playground.Citizens[i]
+			})
+		}
+		playground.hungryCitizensValid = false
+		playground.hungryCitizens = playground.HungryCitizens()
+		playground.hungryCitizensValid = true
+		for citizenIdx, citizen := range playground.Citizens {
+			newFood := newCitizenFood[citizenIdx]
+			newCitizenFood[citizenIdx] = newCitizenFood[citizenIdx][:0]
+
+			for foodIdx := range newFood {
+				foodPortion := newFood[len(newFood)-foodIdx-1] // first we handle non-hidden food
+				foundFood = append(foundFood, playground.handleFood(citizen, foodPortion)...)
+			}
+		}
+	}
+}
+
+// handleFood asks the citizen's strategy what to do with the food portion,
+// validates and applies the resulting actions. It returns the food which
+// was rediscovered while being hidden.
+func (playground *Playground) handleFood(citizen *Citizen, foodPortion *Food) []*Food {
+	requiredEnergy := playground.Config.RequiredEnergy
+
+	if playground.Institution != nil && !foodPortion.AlreadyHidden && !foodPortion.Taxed {
+		playground.collectTax(citizen, foodPortion)
+		if foodPortion.Amount == 0 {
+			return nil
+		}
+	}
+
+	isGreedy := false
+	if citizen.TotalEnergy()+foodPortion.Amount > requiredEnergy && playground.HasHungryCitizens() {
+		// opportunity for altruism
+		isGreedy = true
+	}
+
+	hadPunishmentOpportunity := playground.Config.EnablePunishment &&
+		citizen.TotalEnergy()+foodPortion.Amount > requiredEnergy && playground.hasGreedyCitizens(citizen)
+	hasPunished := false
+
+	var rediscoveredFood []*Food
+	var helped map[*Citizen]struct{}
+	observation := playground.observe(citizen)
+	actions := citizen.Strategy.HandleFood(observation, *foodPortion)
+	usedFood := uint(0)
+	spentOnPunishments := uint(0)
+	for _, action := range actions {
+		if action.Amount == 0 {
+			panic(fmt.Sprintf("action.Amount == 0: %#+v %#+v", citizen, action))
+		}
+		if action.ActionType != ActionTypePunish && action.Amount > foodPortion.Amount-usedFood {
+			panic(fmt.Sprintf("cheater! %+v: %d > %+v - %d (%T)",
+				action, action.Amount, foodPortion, usedFood, citizen.Strategy))
+		}
+		destination := observation.person(action.Destination)
+		if destination.Citizen != citizen {
+			switch action.ActionType {
+			case ActionTypeEat, ActionTypeHide: // altruism
+				if playground.Config.EnablePrivateMemory {
+					if helped == nil {
+						helped = map[*Citizen]struct{}{}
+					}
+					helped[destination.Citizen] = struct{}{}
+					playground.rememberHelp(citizen, destination.Citizen)
+				}
+				if destination.TotalEnergy() < requiredEnergy &&
+					destination.TotalEnergy()+action.Amount >= requiredEnergy {
+					citizen.SavedPeople++
+					citizen.savedPeopleLog.add(playground.weekID)
+					destination.Citizen.WasSavedTimes++
+					destination.Citizen.wasSavedTimesLog.add(playground.weekID)
+					if playground.Config.EnableGossip {
+						playground.observeHelp(citizen, destination.Citizen)
+					}
+					isGreedy = false
+				}
+			case ActionTypeLend:
+				// a loan is not a gift, but it is not a refusal either
+				if helped == nil {
+					helped = map[*Citizen]struct{}{}
+				}
+				helped[destination.Citizen] = struct{}{}
+				if destination.TotalEnergy() < requiredEnergy &&
+					destination.TotalEnergy()+action.Amount >= requiredEnergy {
+					isGreedy = false
+				}
+			}
+		}
+		if action.ActionType == ActionTypePunish {
+			// the punishment is paid from the reserves, not from the portion
+			spentOnPunishments += action.Amount
+		} else {
+			usedFood += action.Amount
+		}
+		switch action.ActionType {
+		case ActionTypeEat:
+			destination.HadEat += action.Amount
+		case ActionTypeHide:
+			if !foodPortion.AlreadyHidden && playground.Rand.Float64() < playground.Config.HiddenFoodRediscoveryProbability {
+				rediscoveredFood = append(rediscoveredFood, &Food{
+					Amount: action.Amount,
+					Taxed:  foodPortion.Taxed,
+				})
+			} else {
+				destination.hide(action.Amount)
+			}
+		case ActionTypeLend, ActionTypeRepay:
+			if !playground.Config.EnableLending {
+				panic(fmt.Sprintf("lending is disabled: %+v (%T)", action, citizen.Strategy))
+			}
+			if action.ActionType == ActionTypeLend {
+				playground.lend(citizen, destination, action.Amount)
+			} else {
+				playground.repay(citizen, destination, action.Amount)
+			}
+		case ActionTypePunish:
+			if !playground.Config.EnablePunishment {
+				panic(fmt.Sprintf("punishment is disabled: %+v (%T)", action, citizen.Strategy))
+			}
+			playground.punish(citizen, destination, action.Amount)
+			hasPunished = true
+		case ActionTypeGuard:
+			if !playground.Config.EnableTheft {
+				panic(fmt.Sprintf("theft is disabled: %+v (%T)", action, citizen.Strategy))
+			}
+			if destination.Citizen != citizen {
+				panic(fmt.Sprintf("guarding somebody else: %+v (%T)", action, citizen.Strategy))
+			}
+			destination.guard += action.Amount
+		default:
+			panic(fmt.Sprintf("unknown action: %v", action.ActionType))
+		}
+	}
+	if usedFood != foodPortion.Amount {
+		panic(fmt.Sprintf("something is wrong: %d != %+v (%T)",
+			usedFood, foodPortion, citizen.Strategy))
+	}
+	if !playground.Config.AllowSuicidalStrategies &&
+		citizen.TotalEnergy() < requiredEnergy &&
+		citizen.TotalEnergy()-citizen.HadEat+foodPortion.Amount+spentOnPunishments >= requiredEnergy {
+		panic(fmt.Sprintf("suicide strategy: 0x%p:%#+v %#+v %#+v %v",
+			citizen, citizen, foodPortion, actions, citizen.TotalEnergy()))
+	}
+
+	if isGreedy && playground.Config.EnablePrivateMemory {
+		for _, hungryCitizen := range playground.HungryCitizens() {
+			if _, ok := helped[hungryCitizen]; ok || hungryCitizen == citizen {
+				continue
+			}
+			playground.rememberRefusal(citizen, hungryCitizen)
+		}
+	}
+
+	if isGreedy && playground.Config.EnableGossip {
+		playground.observeGreed(citizen)
+	}
+
+	if playground.Config.EnablePunishment {
+		citizen.SpottedAsNonPunisherLastTime = hadPunishmentOpportunity && !hasPunished
+	}
+
+	citizen.SpottedAsGreedyOnce = citizen.SpottedAsGreedyOnce || isGreedy
+	// a thief caught this week stays spotted until the next week
+	citizen.SpottedAsGreedyLastTime = isGreedy || citizen.CaughtStealingInLastWeeks(1) > 0
+	return rediscoveredFood
+}
+
+func (playground *Playground) dyingFromHunger() {
+	requiredEnergy := playground.Config.RequiredEnergy
+	if playground.School != nil {
+		for _, child := range append([]*Child{}, playground.School.Children...) {
+			playground.childDyingFromHunger(child)
+		}
+	}
+	for _, citizen := range playground.Citizens {
+		for _, child := range append([]*Child{}, citizen.Children...) {
+			playground.childDyingFromHunger(child)
+		}
+	}
+	// the children are handled first, so that the orphans of the citizens
+	// dying below are not handled twice by their adopters
+	for _, citizen := range append([]*Citizen{}, playground.Citizens...) {
+		citizen.HasEnergy += citizen.EatEnergy()
+		citizen.HadEat = 0
+		if citizen.HasEnergy < requiredEnergy {
+			if citizen.TotalEnergy() >= requiredEnergy {
+				panic(fmt.Sprintf("invalid strategy: %+v", citizen))
+			}
+			playground.RemoveCitizen(citizen)
+			playground.weekStats.DeathsOfHunger++
+			continue
+		}
+		citizen.HasEnergy -= requiredEnergy
+	}
+}
+
+func (playground *Playground) childDyingFromHunger(child *Child) {
+	requiredEnergy := playground.Config.RequiredEnergy
+	child.HasEnergy += child.EatEnergy()
+	child.HadEat = 0
+	if child.TotalEnergy() < requiredEnergy {
+		child.Die()
+		playground.weekStats.ChildDeathsOfHunger++
+		if child.IsOrphan {
+			playground.weekStats.OrphanDeathsOfHunger++
+		}
+		return
+	}
+	child.HasEnergy -= requiredEnergy
+}
+
+func (playground *Playground) generateBabies() {
+	for _, citizen := range playground.Citizens {
+		alreadyHasUnbornBaby := false
+		for _, child := range citizen.Children {
+			if child.AgeInWeeks < 40 {
+				alreadyHasUnbornBaby = true
+			}
+		}
+		if alreadyHasUnbornBaby {
+			continue
+		}
+		if citizen.HasEnergy >= playground.Config.StartBabyEnergy {
+			citizen.CreateBaby()
+			playground.weekStats.Births++
+		}
+	}
+}
+
+func (playground *Playground) aging() {
+	// the children of the citizens died this week are handed over only
+	// in the death phase, but they grow up as well
+	for _, deadCitizen := range playground.dead {
+		for _, child := range deadCitizen.Children {
+			child.AgeInWeeks++
+		}
+	}
+	if playground.School != nil {
+		for _, child := range playground.School.Children {
+			child.AgeInWeeks++
+		}
+	}
+	for _, citizen := range append([]*Citizen{}, playground.Citizens...) {
+		for _, child := range citizen.Children {
+			child.AgeInWeeks++
+		}
+		citizen.AgeInWeeks++
+		if citizen.AgeInWeeks > playground.Config.PersonExpirationInWeeks {
+			playground.RemoveCitizen(citizen)
+			playground.weekStats.DeathsOfAging++
+		}
+	}
+}
+
+func (playground *Playground) graduation() {
+	var children []*Child
+	if playground.School != nil {
+		children = append(children, playground.School.Children...)
+	}
+	for _, citizen := range playground.Citizens {
+		children = append(children, citizen.Children...)
+	}
+	for _, child := range children {
+		if child.AgeInWeeks > playground.Config.PersonGraduationInWeeks {
+			child.Graduate()
+			playground.weekStats.Graduations++
+			if child.IsOrphan {
+				playground.weekStats.OrphanGraduations++
+			}
+		}
+	}
+}
+
+func (playground *Playground) changeStrategies() {
+	if playground.Config.ChangeStrategyExponent == 0 || playground.Config.TransmissionModel == TransmissionModelVertical {
+		return
+	}
+	nextStrategy := make([]Strategy, len(playground.Citizens))
+	for citizenIdx, citizen := range playground.Citizens {
+		if playground.Rand.Float64() < citizen.ChangeStrategyProbability {
+			nextStrategy[citizenIdx] = playground.transmit(citizen.Strategy, playground.transmittedStrategy())
+		}
+	}
+	for citizenIdx, strategy := range nextStrategy {
+		if strategy == nil || strategy == playground.Citizens[citizenIdx].Strategy {
+			continue
+		}
+		playground.cultureStatsOf(playground.Citizens[citizenIdx].Strategy).ConvertedFrom++
+		playground.cultureStatsOf(strategy).ConvertedTo++
+		playground.setStrategy(playground.Citizens[citizenIdx], strategy)
+		playground.weekStats.StrategySwitches++
+	}
+}
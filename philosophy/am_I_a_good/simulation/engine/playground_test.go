@@ -0,0 +1,212 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// scriptedStrategy returns the actions built by the test.
+type scriptedStrategy struct {
+	actions func(observation *Observation, food Food) []Action
+}
+
+func (strategy *scriptedStrategy) HandleFood(observation *Observation, food Food) []Action {
+	return strategy.actions(observation, food)
+}
+
+func TestHandleFoodValidation(t *testing.T) {
+	const portion = 2000
+	for _, tc := range []struct {
+		name      string
+		configure func(cfg *Config)
+		actions   func(observation *Observation, self, other PersonID) []Action
+		panic     string
+	}{
+		{
+			name: "valid",
+			actions: func(observation *Observation, self, other PersonID) []Action {
+				return []Action{{ActionType: ActionTypeEat, Amount: portion, Destination: self}}
+			},
+		},
+		{
+			name: "zero amount",
+			actions: func(observation *Observation, self, other PersonID) []Action {
+				return []Action{
+					{ActionType: ActionTypeEat, Amount: 0, Destination: self},
+					{ActionType: ActionTypeEat, Amount: portion, Destination: self},
+				}
+			},
+			panic: "action.Amount == 0",
+		},
+		{
+			name: "more than the portion",
+			actions: func(observation *Observation, self, other PersonID) []Action {
+				return []Action{
+					{ActionType: ActionTypeEat, Amount: portion / 2, Destination: self},
+					{ActionType: ActionTypeHide, Amount: portion/2 + 1, Destination: self},
+				}
+			},
+			panic: "cheater!",
+		},
+		{
+			name: "unused food",
+			actions: func(observation *Observation, self, other PersonID) []Action {
+				return []Action{{ActionType: ActionTypeEat, Amount: portion - 1, Destination: self}}
+			},
+			panic: "something is wrong",
+		},
+		{
+			name: "not observed destination",
+			actions: func(observation *Observation, self, other PersonID) []Action {
+				return []Action{{ActionType: ActionTypeEat, Amount: portion, Destination: other}}
+			},
+			panic: "was not observed",
+		},
+		{
+			name: "nonexistent destination",
+			actions: func(observation *Observation, self, other PersonID) []Action {
+				return []Action{{ActionType: ActionTypeEat, Amount: portion, Destination: other + 100}}
+			},
+			panic: "was not observed",
+		},
+		{
+			name: "unknown action",
+			actions: func(observation *Observation, self, other PersonID) []Action {
+				return []Action{{ActionType: ActionTypeUndefined, Amount: portion, Destination: self}}
+			},
+			panic: "unknown action",
+		},
+		{
+			name: "lending is disabled",
+			actions: func(observation *Observation, self, other PersonID) []Action {
+				observation.People()
+				return []Action{{ActionType: ActionTypeLend, Amount: portion, Destination: other}}
+			},
+			panic: "lending is disabled",
+		},
+		{
+			name: "punishment is disabled",
+			actions: func(observation *Observation, self, other PersonID) []Action {
+				observation.People()
+				return []Action{{ActionType: ActionTypePunish, Amount: portion, Destination: other}}
+			},
+			panic: "punishment is disabled",
+		},
+		{
+			name: "theft is disabled",
+			actions: func(observation *Observation, self, other PersonID) []Action {
+				return []Action{{ActionType: ActionTypeGuard, Amount: portion, Destination: self}}
+			},
+			panic: "theft is disabled",
+		},
+		{
+			name:      "guarding somebody else",
+			configure: func(cfg *Config) { cfg.EnableTheft = true },
+			actions: func(observation *Observation, self, other PersonID) []Action {
+				observation.People()
+				return []Action{{ActionType: ActionTypeGuard, Amount: portion, Destination: other}}
+			},
+			panic: "guarding somebody else",
+		},
+		{
+			name: "suicide",
+			actions: func(observation *Observation, self, other PersonID) []Action {
+				observation.People()
+				return []Action{{ActionType: ActionTypeEat, Amount: portion, Destination: other}}
+			},
+			panic: "suicide strategy",
+		},
+		{
+			name:      "allowed suicide",
+			configure: func(cfg *Config) { cfg.AllowSuicidalStrategies = true },
+			actions: func(observation *Observation, self, other PersonID) []Action {
+				observation.People()
+				return []Action{{ActionType: ActionTypeEat, Amount: portion, Destination: other}}
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.EnableInstitution = false
+			cfg.EnableLending = false
+			cfg.EnablePunishment = false
+			cfg.EnableTheft = false
+			cfg.AllowSuicidalStrategies = false
+			if tc.configure != nil {
+				tc.configure(&cfg)
+			}
+			playground := NewPlayground(cfg, 0)
+			strategy := &scriptedStrategy{}
+			playground.AddCitizens(strategy, 2)
+			citizen, other := playground.Citizens[0], playground.Citizens[1]
+			citizen.HasEnergy = 0
+			strategy.actions = func(observation *Observation, food Food) []Action {
+				if food.Amount != portion {
+					t.Errorf("the strategy got %d instead of %d", food.Amount, portion)
+				}
+				return tc.actions(observation, citizen.id, other.id)
+			}
+
+			var recovered any
+			func() {
+				defer func() {
+					recovered = recover()
+				}()
+				playground.handleFood(citizen, &Food{Amount: portion})
+			}()
+
+			switch {
+			case tc.panic == "" && recovered != nil:
+				t.Errorf("unexpected panic: %v", recovered)
+			case tc.panic != "" && recovered == nil:
+				t.Errorf("expected a panic containing '%s'", tc.panic)
+			case tc.panic != "" && !strings.Contains(fmt.Sprint(recovered), tc.panic):
+				t.Errorf("expected a panic containing '%s', got: %v", tc.panic, recovered)
+			}
+			if tc.panic == "" && citizen.HadEat+other.HadEat != portion {
+				t.Errorf("%d of %d is eaten", citizen.HadEat+other.HadEat, portion)
+			}
+		})
+	}
+}
+
+func TestHandleFoodGreed(t *testing.T) {
+	const portion = 2000
+	for _, tc := range []struct {
+		name        string
+		shareAmount uint
+		greedy      bool
+		saved       uint
+	}{
+		{name: "eats everything", greedy: true},
+		{name: "saves the hungry", shareAmount: 1000, saved: 1},
+		{name: "does not share enough", shareAmount: 500, greedy: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			playground := NewPlayground(DefaultConfig(), 1)
+			strategy := &scriptedStrategy{}
+			playground.AddCitizens(strategy, 2)
+			citizen, other := playground.Citizens[0], playground.Citizens[1]
+			citizen.HasEnergy = 0
+			strategy.actions = func(observation *Observation, food Food) []Action {
+				actions := []Action{{ActionType: ActionTypeEat, Amount: food.Amount - tc.shareAmount, Destination: citizen.id}}
+				if tc.shareAmount > 0 {
+					observation.HungryPeople()
+					actions = append(actions, Action{ActionType: ActionTypeEat, Amount: tc.shareAmount, Destination: other.id})
+				}
+				return actions
+			}
+
+			playground.handleFood(citizen, &Food{Amount: portion})
+
+			if citizen.SpottedAsGreedyLastTime != tc.greedy || citizen.SpottedAsGreedyOnce != tc.greedy {
+				t.Errorf("spotted as greedy: %v (once: %v), expected %v",
+					citizen.SpottedAsGreedyLastTime, citizen.SpottedAsGreedyOnce, tc.greedy)
+			}
+			if citizen.SavedPeople != tc.saved || other.WasSavedTimes != tc.saved {
+				t.Errorf("saved %d, was saved %d, expected %d", citizen.SavedPeople, other.WasSavedTimes, tc.saved)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package engine
+
+import (
+	"fmt"
+)
+
+func (playground *Playground) punish(punisher *Citizen, target *Person, amount uint) {
+	if target.Citizen == punisher {
+		panic(fmt.Sprintf("punishing itself or own children: %#+v (%T)", punisher, punisher.Strategy))
+	}
+	if amount > punisher.HasEnergy+punisher.OwnsFood {
+		panic(fmt.Sprintf("cheater! punishing for %d having only %d energy and %d hidden food (%T)",
+			amount, punisher.HasEnergy, punisher.OwnsFood, punisher.Strategy))
+	}
+	punisher.HasEnergy -= amount - punisher.takeHiddenFood(amount)
+
+	fine := uint(float64(amount) * playground.Config.PunishmentEfficiency)
+	fine -= target.takeHiddenFood(fine)
+	if fine > target.HasEnergy {
+		fine = target.HasEnergy
+	}
+	target.HasEnergy -= fine
+	playground.hungryCitizensValid = false
+
+	punisher.Punished++
+	target.Citizen.WasPunishedTimes++
+	playground.weekStats.Punishments++
+}
+
+// hasGreedyCitizens returns true if any citizen except the given one
+// was greedy the last time.
+func (playground *Playground) hasGreedyCitizens(except *Citizen) bool {
+	for _, citizen := range playground.Citizens {
+		if citizen != except && citizen.SpottedAsGreedyLastTime {
+			return true
+		}
+	}
+	return false
+}
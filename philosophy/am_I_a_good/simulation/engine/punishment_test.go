@@ -0,0 +1,124 @@
+package engine
+
+import (
+	"testing"
+)
+
+func punishmentPlayground(citizens uint) *Playground {
+	cfg := DefaultConfig()
+	cfg.EnablePunishment = true
+	cfg.PunishmentEfficiency = 3
+	playground := NewPlayground(cfg, 1)
+	playground.AddCitizens(&scriptedStrategy{}, citizens)
+	return playground
+}
+
+func TestPunish(t *testing.T) {
+	playground := punishmentPlayground(2)
+	punisher, target := playground.Citizens[0], playground.Citizens[1]
+	punisher.HasEnergy, punisher.OwnsFood = 1000, 0
+	target.HasEnergy, target.OwnsFood = 2000, 0
+	punisher.hide(100)
+	target.hide(200)
+
+	// the hidden food is spent first, then the energy
+	playground.punish(punisher, &target.Person, 300)
+	if punisher.OwnsFood != 0 || punisher.HasEnergy != 800 {
+		t.Errorf("the punisher has %d energy and %d hidden food left, expected 800 and 0",
+			punisher.HasEnergy, punisher.OwnsFood)
+	}
+	if target.OwnsFood != 0 || target.HasEnergy != 1300 {
+		t.Errorf("the target has %d energy and %d hidden food left, expected 1300 and 0",
+			target.HasEnergy, target.OwnsFood)
+	}
+	if punisher.Punished != 1 || target.WasPunishedTimes != 1 || playground.weekStats.Punishments != 1 {
+		t.Errorf("the punishment is not counted: %d, %d, %+v",
+			punisher.Punished, target.WasPunishedTimes, playground.weekStats)
+	}
+
+	// the fine does not make the energy negative
+	playground.punish(punisher, &target.Person, 500)
+	if target.HasEnergy != 0 || punisher.HasEnergy != 300 {
+		t.Errorf("unexpected energy: the target %d, the punisher %d", target.HasEnergy, punisher.HasEnergy)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic on punishing beyond the reserves")
+		}
+	}()
+	playground.punish(punisher, &target.Person, 301)
+}
+
+func TestHandleFoodPunishment(t *testing.T) {
+	const portion = 2000
+	for _, tc := range []struct {
+		name        string
+		disabled    bool
+		punish      bool
+		nonPunisher bool
+		panicking   bool
+	}{
+		{
+			name:   "punishes",
+			punish: true,
+		},
+		{
+			name:        "does not punish",
+			nonPunisher: true,
+		},
+		{
+			name:      "disabled",
+			disabled:  true,
+			punish:    true,
+			panicking: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			playground := punishmentPlayground(2)
+			playground.Config.EnablePunishment = !tc.disabled
+			citizen, other := playground.Citizens[0], playground.Citizens[1]
+			cfg := &playground.Config
+			citizen.HasEnergy, citizen.OwnsFood = cfg.RequiredEnergy, 0
+			citizen.hide(100)
+			other.HasEnergy = cfg.RequiredEnergy
+			other.SpottedAsGreedyLastTime = true
+			citizen.Strategy.(*scriptedStrategy).actions = func(observation *Observation, _ Food) []Action {
+				observation.People()
+				// the punishment does not take the food of the portion
+				actions := []Action{{ActionType: ActionTypeEat, Amount: portion, Destination: citizen.id}}
+				if tc.punish {
+					actions = append(actions, Action{ActionType: ActionTypePunish, Amount: 300, Destination: other.id})
+				}
+				return actions
+			}
+
+			var recovered any
+			func() {
+				defer func() { recovered = recover() }()
+				playground.handleFood(citizen, &Food{Amount: portion})
+			}()
+			if (recovered != nil) != tc.panicking {
+				t.Fatalf("panic: %v", recovered)
+			}
+			if tc.panicking {
+				return
+			}
+
+			expectedEnergy := cfg.RequiredEnergy
+			if tc.punish {
+				expectedEnergy -= 200
+			}
+			if citizen.HadEat != portion || citizen.HasEnergy != expectedEnergy {
+				t.Errorf("has eaten %d and has %d energy, expected %d and %d",
+					citizen.HadEat, citizen.HasEnergy, portion, expectedEnergy)
+			}
+			if punished := other.WasPunishedTimes > 0; punished != tc.punish {
+				t.Errorf("punished: %v, expected %v", punished, tc.punish)
+			}
+			if citizen.SpottedAsNonPunisherLastTime != tc.nonPunisher {
+				t.Errorf("spotted as a non-punisher: %v, expected %v", citizen.SpottedAsNonPunisherLastTime, tc.nonPunisher)
+			}
+		})
+	}
+}
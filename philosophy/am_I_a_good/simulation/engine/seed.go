@@ -0,0 +1,22 @@
+package engine
+
+import (
+	"time"
+)
+
+// NewMasterSeed returns a seed to be used if the user did not provide one.
+func NewMasterSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// TrySeed derives the seed of the try tryIdx from the master seed.
+//
+// The seeds of neighbouring tries are decorrelated with splitmix64, so that
+// tries do not share PRNG sequences while each of them could be replayed
+// individually knowing only the master seed and the try index.
+func TrySeed(masterSeed int64, tryIdx uint) int64 {
+	z := uint64(masterSeed) + (uint64(tryIdx)+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
+}
@@ -0,0 +1,40 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTrySeed(t *testing.T) {
+	for _, tc := range []struct {
+		masterSeed int64
+		tryIdx     uint
+		expected   int64
+	}{
+		// the first outputs of the reference splitmix64 seeded by 0
+		{masterSeed: 0, tryIdx: 0, expected: -2152535657050944081},
+		{masterSeed: 0, tryIdx: 1, expected: 7960286522194355700},
+		{masterSeed: 0, tryIdx: 999, expected: 1504391059752320062},
+		{masterSeed: 1, tryIdx: 0, expected: -7995527694508729151},
+		{masterSeed: -1, tryIdx: 0, expected: -1956407806741107680},
+		{masterSeed: 42, tryIdx: 1, expected: 2949826092126892291},
+	} {
+		if seed := TrySeed(tc.masterSeed, tc.tryIdx); seed != tc.expected {
+			t.Errorf("TrySeed(%d, %d) = %d, expected %d", tc.masterSeed, tc.tryIdx, seed, tc.expected)
+		}
+	}
+}
+
+func TestTrySeedUnique(t *testing.T) {
+	seen := map[int64]string{}
+	for _, masterSeed := range []int64{0, 1, 2, -1, 1 << 40} {
+		for tryIdx := uint(0); tryIdx < 1000; tryIdx++ {
+			seed := TrySeed(masterSeed, tryIdx)
+			key := fmt.Sprintf("master seed %d, try #%d", masterSeed, tryIdx)
+			if previous, ok := seen[seed]; ok {
+				t.Fatalf("%s has the same seed %d as %s", key, seed, previous)
+			}
+			seen[seed] = key
+		}
+	}
+}
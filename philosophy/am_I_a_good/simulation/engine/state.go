@@ -0,0 +1,51 @@
+package engine
+
+// StatefulStrategy is an optional interface of a Strategy which keeps
+// an internal state for every citizen following it (a Strategy instance
+// is shared by all its citizens, so it cannot remember anything about
+// an individual citizen by itself).
+//
+// The state is created when the citizen starts following the strategy
+// (when it is added, graduates or switches the strategy) and is kept
+// in Citizen.StrategyState until it switches to another one.
+type StatefulStrategy interface {
+	Strategy
+
+	// NewCitizenState returns the initial state of the citizen. It may
+	// use the Rand* methods of the observation (e.g. to draw individual
+	// traits).
+	NewCitizenState(observation *Observation) any
+}
+
+// CitizenStateFinalizer is an optional interface of a StatefulStrategy
+// which is notified when the citizen stops following the strategy:
+// when it dies or switches to another strategy, or when the try of
+// the Experiment ends (then died is false).
+type CitizenStateFinalizer interface {
+	FinishCitizenState(state any, died bool)
+}
+
+// setStrategy makes the citizen follow the strategy.
+func (playground *Playground) setStrategy(citizen *Citizen, strategy Strategy) {
+	playground.finishStrategyState(citizen, false)
+	citizen.Strategy = strategy
+	citizen.StrategyState = nil
+	if stateful, ok := strategy.(StatefulStrategy); ok {
+		citizen.StrategyState = stateful.NewCitizenState(playground.observe(citizen))
+	}
+}
+
+func (playground *Playground) finishStrategyState(citizen *Citizen, died bool) {
+	if finalizer, ok := citizen.Strategy.(CitizenStateFinalizer); ok {
+		finalizer.FinishCitizenState(citizen.StrategyState, died)
+	}
+}
+
+// finishStrategyStates finishes the strategy states of all the alive
+// citizens at the end of a try.
+func (playground *Playground) finishStrategyStates() {
+	for _, citizen := range playground.Citizens {
+		playground.finishStrategyState(citizen, false)
+		citizen.StrategyState = nil
+	}
+}
@@ -0,0 +1,125 @@
+package engine
+
+import (
+	"sync"
+	"testing"
+)
+
+// countedState is the state of a countingStrategy citizen.
+type countedState struct {
+	citizen  *Citizen
+	finished uint
+	died     bool
+}
+
+// countingStrategy remembers every state it created.
+type countingStrategy struct {
+	scriptedStrategy
+	locker sync.Mutex
+	states []*countedState
+}
+
+var _ CitizenStateFinalizer = (*countingStrategy)(nil)
+
+func (strategy *countingStrategy) NewCitizenState(observation *Observation) any {
+	strategy.locker.Lock()
+	defer strategy.locker.Unlock()
+	state := &countedState{citizen: observation.citizen}
+	strategy.states = append(strategy.states, state)
+	return state
+}
+
+func (strategy *countingStrategy) FinishCitizenState(state any, died bool) {
+	counted := state.(*countedState)
+	counted.finished++
+	counted.died = died
+}
+
+func TestStrategyState(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ChangeStrategyExponent = 0
+	playground := NewPlayground(cfg, 1)
+	stateful := &countingStrategy{scriptedStrategy: scriptedStrategy{actions: eatEverything}}
+	stateless := &scriptedStrategy{actions: eatEverything}
+	playground.AddCitizens(stateful, 3)
+	playground.AddCitizens(stateless, 1)
+
+	if len(stateful.states) != 3 {
+		t.Fatalf("%d states were created for 3 citizens", len(stateful.states))
+	}
+	for idx, state := range stateful.states {
+		citizen := playground.Citizens[idx]
+		if citizen.StrategyState != state || state.citizen != citizen {
+			t.Errorf("citizen #%d does not keep its state", idx)
+		}
+	}
+	if playground.Citizens[3].StrategyState != nil {
+		t.Errorf("a stateless strategy got a state")
+	}
+
+	switched, died := playground.Citizens[0], playground.Citizens[1]
+	playground.setStrategy(switched, stateless)
+	if switched.StrategyState != nil {
+		t.Errorf("the state was kept after switching to a stateless strategy")
+	}
+	playground.setStrategy(playground.Citizens[3], stateful)
+	if len(stateful.states) != 4 || playground.Citizens[3].StrategyState != stateful.states[3] {
+		t.Errorf("no state was created on switching to the stateful strategy")
+	}
+	playground.RemoveCitizen(died)
+
+	for _, tc := range []struct {
+		name     string
+		state    *countedState
+		finished uint
+		died     bool
+	}{
+		{"switched", stateful.states[0], 1, false},
+		{"died", stateful.states[1], 1, true},
+		{"alive", stateful.states[2], 0, false},
+		{"converted", stateful.states[3], 0, false},
+	} {
+		if tc.state.finished != tc.finished || tc.state.died != tc.died {
+			t.Errorf("%s: finished %d times (died: %v) instead of %d (died: %v)",
+				tc.name, tc.state.finished, tc.state.died, tc.finished, tc.died)
+		}
+	}
+}
+
+func TestExperimentFinishesStrategyStates(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AmountOfPortions = 5
+	strategy := &countingStrategy{scriptedStrategy: scriptedStrategy{actions: eatEverything}}
+	var alive []*Citizen
+	experiment := &Experiment{
+		Config: cfg,
+		Tries:  4,
+		Weeks:  50,
+		Populate: func(playground *Playground) {
+			playground.AddCitizens(strategy, 10)
+		},
+		OnFinish: func(tryIdx uint, playground *Playground) {
+			for _, citizen := range playground.Citizens {
+				if citizen.StrategyState.(*countedState).finished != 0 {
+					t.Errorf("try #%d: a state was finished before OnFinish", tryIdx)
+				}
+			}
+			alive = append(alive, playground.Citizens...)
+		},
+	}
+	experiment.Run()
+
+	if len(alive) == 0 || len(alive) == len(strategy.states) {
+		t.Fatalf("the test expects some of %d citizens to survive, but %d did", len(strategy.states), len(alive))
+	}
+	for _, state := range strategy.states {
+		if state.finished != 1 || state.died != state.citizen.isDead {
+			t.Errorf("a state was finished %d times (died: %v, dead: %v)", state.finished, state.died, state.citizen.isDead)
+		}
+	}
+	for _, citizen := range alive {
+		if citizen.StrategyState != nil {
+			t.Errorf("the state of an alive citizen was kept after the try")
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"reflect"
+)
+
+// WeekStats counts the events happened on a playground during a week.
+type WeekStats struct {
+	Births              uint `json:"births"`
+	Graduations         uint `json:"graduations"`
+	DeathsOfHunger      uint `json:"deaths_of_hunger"`
+	DeathsOfAging       uint `json:"deaths_of_aging"`
+	ChildDeathsOfHunger uint `json:"child_deaths_of_hunger"`
+	StrategySwitches    uint `json:"strategy_switches"`
+	LoansIssued         uint `json:"loans_issued"`
+	LoansRepaid         uint `json:"loans_repaid"`
+	LoanDefaults        uint `json:"loan_defaults"`
+	Punishments         uint `json:"punishments"`
+	TheftAttempts       uint `json:"theft_attempts"`
+	Thefts              uint `json:"thefts"`
+	DetectedThefts      uint `json:"detected_thefts"`
+	TaxCollected        uint `json:"tax_collected"`
+	TaxEvaded           uint `json:"tax_evaded"`
+	TaxEvadersCaught    uint `json:"tax_evaders_caught"`
+	Redistributed       uint `json:"redistributed"`
+	Orphans             uint `json:"orphans"`
+	Adoptions           uint `json:"adoptions"`
+	OrphansLost         uint `json:"orphans_lost"`
+
+	// OrphanDeathsOfHunger and OrphanGraduations are the subsets of
+	// ChildDeathsOfHunger and Graduations.
+	OrphanDeathsOfHunger uint `json:"orphan_deaths_of_hunger"`
+	OrphanGraduations    uint `json:"orphan_graduations"`
+
+	// Inherited and InheritanceLost are the amounts of energy of
+	// the estates of the dead (see Config.InheritancePolicy).
+	Inherited       uint `json:"inherited"`
+	InheritanceLost uint `json:"inheritance_lost"`
+
+	// SameStrategyBirths is the subset of Births made by two parents
+	// following the same strategy.
+	SameStrategyBirths uint `json:"same_strategy_births"`
+
+	GeneMutations uint `json:"gene_mutations"`
+}
+
+// LastWeekStats returns the events of the last iterated week.
+func (playground *Playground) LastWeekStats() WeekStats {
+	return playground.weekStats
+}
+
+// TotalStats returns the events of all the iterated weeks.
+func (playground *Playground) TotalStats() WeekStats {
+	return playground.totalStats
+}
+
+func (stats *WeekStats) add(other WeekStats) {
+	v := reflect.ValueOf(stats).Elem()
+	otherV := reflect.ValueOf(other)
+	for fieldIdx := 0; fieldIdx < v.NumField(); fieldIdx++ {
+		field := v.Field(fieldIdx)
+		field.SetUint(field.Uint() + otherV.Field(fieldIdx).Uint())
+	}
+}
+
+// Children returns the amount of children on the playground
+// (including the school children).
+func (playground *Playground) Children() uint {
+	result := uint(0)
+	if playground.School != nil {
+		result += uint(len(playground.School.Children))
+	}
+	for _, citizen := range playground.Citizens {
+		result += uint(len(citizen.Children))
+	}
+	return result
+}
@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+// Thief is an optional interface of a Strategy which attempts to steal
+// hidden food (see Config.EnableTheft). It is called once a week before
+// the food is found; only the first TheftAttemptsPerWeek targets
+// are attempted.
+type Thief interface {
+	ChooseTheftTargets(observation *Observation) []PersonID
+}
+
+// hide stores the food as a separate stash, so that a theft
+// steals only a single stash.
+func (person *Person) hide(amount uint) {
+	person.OwnsFood += amount
+	person.stashes = append(person.stashes, amount)
+}
+
+// takeHiddenFood removes up to "amount" of hidden food, the latest
+// stashes first, and returns the amount removed.
+func (person *Person) takeHiddenFood(amount uint) uint {
+	taken := uint(0)
+	for len(person.stashes) > 0 && taken < amount {
+		lastIdx := len(person.stashes) - 1
+		take := person.stashes[lastIdx]
+		if take > amount-taken {
+			take = amount - taken
+			person.stashes[lastIdx] -= take
+		} else {
+			person.stashes = person.stashes[:lastIdx]
+		}
+		taken += take
+	}
+	person.OwnsFood -= taken
+	return taken
+}
+
+// takeAllHiddenFood removes all the hidden food and returns its amount.
+func (person *Person) takeAllHiddenFood() uint {
+	result := person.OwnsFood
+	person.OwnsFood = 0
+	person.stashes = person.stashes[:0]
+	return result
+}
+
+// StashesCount returns the amount of separate portions of the hidden food.
+func (person *Person) StashesCount() uint {
+	return uint(len(person.stashes))
+}
+
+// Guard returns the amount of food spent to guard the person's
+// hidden food since the last theft phase.
+func (person *Person) Guard() uint {
+	return person.guard
+}
+
+func (playground *Playground) theftSuccessProbability(target *Person) float64 {
+	cfg := &playground.Config
+	if cfg.GuardHalvingAmount == 0 {
+		return cfg.TheftSuccessProbability
+	}
+	return cfg.TheftSuccessProbability * math.Pow(0.5, float64(target.guard)/float64(cfg.GuardHalvingAmount))
+}
+
+// steal makes the thief attempt to steal a random stash of the target.
+func (playground *Playground) steal(thief *Citizen, target *Person) {
+	cfg := &playground.Config
+	if target.Citizen == thief {
+		panic(fmt.Sprintf("stealing from itself or own children: %#+v (%T)", thief, thief.Strategy))
+	}
+
+	playground.weekStats.TheftAttempts++
+	thief.TheftAttempts++
+	if len(target.stashes) > 0 && playground.Rand.Float64() < playground.theftSuccessProbability(target) {
+		stashIdx := playground.RandUintn(uint(len(target.stashes)))
+		amount := target.stashes[stashIdx]
+		target.stashes[stashIdx] = target.stashes[len(target.stashes)-1]
+		target.stashes = target.stashes[:len(target.stashes)-1]
+		target.OwnsFood -= amount
+		thief.hide(amount)
+		playground.weekStats.Thefts++
+	}
+
+	if playground.Rand.Float64() < cfg.TheftDetectionProbability {
+		thief.SpottedAsThief = true
+		thief.SpottedAsGreedyOnce = true
+		thief.SpottedAsGreedyLastTime = true
+		thief.theftLog.add(playground.weekID)
+		playground.weekStats.DetectedThefts++
+		if cfg.EnablePrivateMemory && target.Citizen != nil {
+			playground.rememberRefusal(thief, target.Citizen)
+		}
+		if cfg.EnableGossip && target.Citizen != nil {
+			thief.gossip.greedyWitness = target.Citizen
+		}
+	}
+}
+
+// thefts lets the thieves steal and then resets the guards.
+func (playground *Playground) thefts() {
+	cfg := &playground.Config
+	for _, citizen := range append([]*Citizen{}, playground.Citizens...) {
+		thief, ok := citizen.Strategy.(Thief)
+		if !ok {
+			continue
+		}
+		observation := playground.observe(citizen)
+		targets := thief.ChooseTheftTargets(observation)
+		if uint(len(targets)) > cfg.TheftAttemptsPerWeek {
+			targets = targets[:cfg.TheftAttemptsPerWeek]
+		}
+		for _, target := range targets {
+			playground.steal(citizen, observation.person(target))
+		}
+	}
+	for _, person := range playground.People() {
+		person.guard = 0
+	}
+}
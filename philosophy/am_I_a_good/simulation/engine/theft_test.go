@@ -0,0 +1,121 @@
+package engine
+
+import (
+	"testing"
+)
+
+// scriptedThief steals from the targets chosen by the test.
+type scriptedThief struct {
+	scriptedStrategy
+	targets []*Person
+}
+
+func (strategy *scriptedThief) ChooseTheftTargets(observation *Observation) []PersonID {
+	observation.People()
+	var result []PersonID
+	for _, target := range strategy.targets {
+		result = append(result, target.id)
+	}
+	return result
+}
+
+func theftPlayground(successProbability, detectionProbability float64) (*Playground, *scriptedThief) {
+	cfg := DefaultConfig()
+	cfg.EnableTheft = true
+	cfg.TheftSuccessProbability = successProbability
+	cfg.TheftDetectionProbability = detectionProbability
+	cfg.TheftAttemptsPerWeek = 1
+	playground := NewPlayground(cfg, 1)
+	thief := &scriptedThief{}
+	playground.AddCitizens(thief, 1)
+	playground.AddCitizens(&scriptedStrategy{}, 1)
+	return playground, thief
+}
+
+func TestStashes(t *testing.T) {
+	playground, _ := theftPlayground(1, 0)
+	person := &playground.Citizens[1].Person
+	person.OwnsFood = 0
+	for _, amount := range []uint{100, 200, 300} {
+		person.hide(amount)
+	}
+
+	// the latest stashes are taken first
+	if taken := person.takeHiddenFood(400); taken != 400 || person.OwnsFood != 200 || person.StashesCount() != 2 {
+		t.Errorf("took %d, left %d in %d stashes", taken, person.OwnsFood, person.StashesCount())
+	}
+	if taken := person.takeHiddenFood(1000); taken != 200 || person.OwnsFood != 0 || person.StashesCount() != 0 {
+		t.Errorf("took %d, left %d in %d stashes", taken, person.OwnsFood, person.StashesCount())
+	}
+
+	person.hide(100)
+	if taken := person.takeAllHiddenFood(); taken != 100 || person.OwnsFood != 0 || person.StashesCount() != 0 {
+		t.Errorf("took %d, left %d in %d stashes", taken, person.OwnsFood, person.StashesCount())
+	}
+}
+
+func TestSteal(t *testing.T) {
+	playground, strategy := theftPlayground(1, 1)
+	thief, victim := playground.Citizens[0], playground.Citizens[1]
+	thief.OwnsFood, victim.OwnsFood = 0, 0
+	victim.hide(100)
+	victim.hide(200)
+	strategy.targets = []*Person{&victim.Person, &victim.Person}
+
+	// only TheftAttemptsPerWeek attempts are made, a single stash each
+	playground.thefts()
+	if thief.TheftAttempts != 1 || victim.StashesCount() != 1 || thief.StashesCount() != 1 ||
+		thief.OwnsFood+victim.OwnsFood != 300 || thief.OwnsFood == 0 {
+		t.Errorf("attempts %d, the thief has %d, the victim has %d in %d stashes",
+			thief.TheftAttempts, thief.OwnsFood, victim.OwnsFood, victim.StashesCount())
+	}
+	stats := playground.weekStats
+	if stats.TheftAttempts != 1 || stats.Thefts != 1 || stats.DetectedThefts != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if !thief.SpottedAsThief || !thief.SpottedAsGreedyOnce || thief.CaughtStealingInLastWeeks(1) != 1 {
+		t.Errorf("the theft is not recorded: %+v", thief)
+	}
+
+	// a detected thief stays spotted as greedy until the next week
+	// even if it is not greedy when it finds the food
+	thief.Strategy.(*scriptedThief).actions = func(observation *Observation, food Food) []Action {
+		return []Action{{ActionType: ActionTypeEat, Amount: food.Amount, Destination: observation.Self.ID()}}
+	}
+	victim.HasEnergy = playground.Config.RequiredEnergy
+	playground.hungryCitizensValid = false
+	playground.handleFood(thief, &Food{Amount: 100})
+	if !thief.SpottedAsGreedyLastTime {
+		t.Errorf("the caught thief is not spotted as greedy")
+	}
+	playground.weekID++
+	playground.handleFood(thief, &Food{Amount: 100})
+	if thief.SpottedAsGreedyLastTime || thief.CaughtStealingInLastWeeks(1) != 0 || thief.CaughtStealingInLastWeeks(2) != 1 {
+		t.Errorf("the thief is still spotted as greedy the next week")
+	}
+}
+
+func TestStealFailures(t *testing.T) {
+	playground, strategy := theftPlayground(0, 0)
+	thief, victim := playground.Citizens[0], playground.Citizens[1]
+	thief.OwnsFood, victim.OwnsFood = 0, 0
+	victim.hide(100)
+	strategy.targets = []*Person{&victim.Person}
+
+	playground.thefts()
+	if thief.OwnsFood != 0 || victim.OwnsFood != 100 || thief.SpottedAsThief || playground.weekStats.TheftAttempts != 1 {
+		t.Errorf("the failed theft changed something: %+v, %+v", thief, playground.weekStats)
+	}
+
+	// the guard halves the probability of a theft every GuardHalvingAmount
+	playground.Config.TheftSuccessProbability = 0.8
+	playground.Config.GuardHalvingAmount = 100
+	victim.guard = 200
+	if p := playground.theftSuccessProbability(&victim.Person); p != 0.2 {
+		t.Errorf("the probability is %f instead of 0.2", p)
+	}
+	playground.thefts()
+	if victim.Guard() != 0 {
+		t.Errorf("the guard is not reset after the theft phase")
+	}
+}
@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// TransmissionModel defines whose strategy a citizen adopts when it
+// changes its strategy (see Citizen.ChangeStrategyProbability).
+type TransmissionModel uint
+
+const (
+	// TransmissionModelRandom copies a uniformly random citizen.
+	TransmissionModelRandom = TransmissionModel(iota)
+
+	// TransmissionModelPayoff copies the citizen with the most energy
+	// among TransmissionSampleSize random citizens.
+	TransmissionModelPayoff
+
+	// TransmissionModelConformist copies the most common strategy
+	// among TransmissionSampleSize random citizens.
+	TransmissionModelConformist
+
+	// TransmissionModelPrestige copies the citizen who saved the most
+	// people among TransmissionSampleSize random citizens.
+	TransmissionModelPrestige
+
+	// TransmissionModelVertical never changes the strategy of
+	// a citizen: strategies are passed from parents to children only.
+	TransmissionModelVertical
+)
+
+func (model TransmissionModel) String() string {
+	switch model {
+	case TransmissionModelRandom:
+		return "random"
+	case TransmissionModelPayoff:
+		return "payoff"
+	case TransmissionModelConformist:
+		return "conformist"
+	case TransmissionModelPrestige:
+		return "prestige"
+	case TransmissionModelVertical:
+		return "vertical"
+	}
+	return "unknown"
+}
+
+// ParseTransmissionModel parses the output of TransmissionModel.String.
+func ParseTransmissionModel(s string) (TransmissionModel, error) {
+	for model := TransmissionModelRandom; model <= TransmissionModelVertical; model++ {
+		if model.String() == s {
+			return model, nil
+		}
+	}
+	return TransmissionModelRandom, fmt.Errorf("unknown transmission model '%s'", s)
+}
+
+func (model TransmissionModel) MarshalText() ([]byte, error) {
+	return []byte(model.String()), nil
+}
+
+func (model *TransmissionModel) UnmarshalText(b []byte) error {
+	parsed, err := ParseTransmissionModel(string(b))
+	if err != nil {
+		return err
+	}
+	*model = parsed
+	return nil
+}
+
+// sampleCitizens returns Config.TransmissionSampleSize random citizens
+// (with repetitions).
+func (playground *Playground) sampleCitizens() []*Citizen {
+	sample := make([]*Citizen, 0, playground.Config.TransmissionSampleSize)
+	for i := uint(0); i < playground.Config.TransmissionSampleSize; i++ {
+		sample = append(sample, playground.Citizens[playground.RandUintn(uint(len(playground.Citizens)))])
+	}
+	return sample
+}
+
+// bestOf returns the citizen with the highest score, the first one
+// on a tie.
+func bestOf(citizens []*Citizen, score func(*Citizen) uint) *Citizen {
+	var best *Citizen
+	for _, citizen := range citizens {
+		if best == nil || score(citizen) > score(best) {
+			best = citizen
+		}
+	}
+	return best
+}
+
+// transmittedStrategy returns the strategy a changing citizen adopts.
+func (playground *Playground) transmittedStrategy() Strategy {
+	switch playground.Config.TransmissionModel {
+	case TransmissionModelRandom:
+		return playground.Citizens[playground.RandUintn(uint(len(playground.Citizens)))].Strategy
+	case TransmissionModelPayoff:
+		return bestOf(playground.sampleCitizens(), func(citizen *Citizen) uint {
+			return citizen.HasEnergy
+		}).Strategy
+	case TransmissionModelConformist:
+		sample := playground.sampleCitizens()
+		count := map[Strategy]uint{}
+		for _, citizen := range sample {
+			count[citizen.Strategy]++
+		}
+		return bestOf(sample, func(citizen *Citizen) uint {
+			return count[citizen.Strategy]
+		}).Strategy
+	case TransmissionModelPrestige:
+		return bestOf(playground.sampleCitizens(), func(citizen *Citizen) uint {
+			return citizen.SavedPeople
+		}).Strategy
+	}
+	panic(fmt.Sprintf("unknown transmission model: %v", playground.Config.TransmissionModel))
+}
@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"testing"
+)
+
+// transmissionPlayground returns a playground of two citizens of
+// the common strategy, a rich one of the rich strategy and a prestigious
+// one of the prestigious strategy.
+func transmissionPlayground(model TransmissionModel) (*Playground, []Strategy) {
+	cfg := DefaultConfig()
+	cfg.TransmissionModel = model
+	cfg.TransmissionSampleSize = 100
+	playground := NewPlayground(cfg, 1)
+	strategies := []Strategy{&scriptedStrategy{}, &scriptedStrategy{}, &scriptedStrategy{}}
+	playground.AddCitizens(strategies[0], 2)
+	playground.AddCitizens(strategies[1], 1)
+	playground.AddCitizens(strategies[2], 1)
+	for _, citizen := range playground.Citizens {
+		citizen.HasEnergy = 1000
+	}
+	playground.Citizens[2].HasEnergy = 2000
+	playground.Citizens[3].SavedPeople = 1
+	return playground, strategies
+}
+
+func TestTransmittedStrategy(t *testing.T) {
+	for _, tc := range []struct {
+		model    TransmissionModel
+		expected int
+	}{
+		{model: TransmissionModelConformist, expected: 0},
+		{model: TransmissionModelPayoff, expected: 1},
+		{model: TransmissionModelPrestige, expected: 2},
+	} {
+		t.Run(tc.model.String(), func(t *testing.T) {
+			playground, strategies := transmissionPlayground(tc.model)
+			for try := 0; try < 10; try++ {
+				if strategy := playground.transmittedStrategy(); strategy != strategies[tc.expected] {
+					t.Errorf("transmitted %p instead of %p", strategy, strategies[tc.expected])
+				}
+			}
+		})
+	}
+
+	playground, strategies := transmissionPlayground(TransmissionModelRandom)
+	seen := map[Strategy]bool{}
+	for try := 0; try < 100; try++ {
+		seen[playground.transmittedStrategy()] = true
+	}
+	if len(seen) != len(strategies) {
+		t.Errorf("the random model transmitted %d strategies of %d", len(seen), len(strategies))
+	}
+}
+
+func TestVerticalTransmission(t *testing.T) {
+	playground, _ := transmissionPlayground(TransmissionModelVertical)
+	var before []Strategy
+	for _, citizen := range playground.Citizens {
+		citizen.ChangeStrategyProbability = 1
+		before = append(before, citizen.Strategy)
+	}
+	playground.changeStrategies()
+	for idx, citizen := range playground.Citizens {
+		if citizen.Strategy != before[idx] {
+			t.Errorf("citizen #%d changed its strategy", idx)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.TransmissionModel = TransmissionModelPayoff
+	cfg.TransmissionSampleSize = 0
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("expected an error for an empty sample")
+	}
+	for model := TransmissionModelRandom; model <= TransmissionModelVertical; model++ {
+		if parsed, err := ParseTransmissionModel(model.String()); err != nil || parsed != model {
+			t.Errorf("unable to parse %v: %v, %v", model, parsed, err)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+module github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation
+
+go 1.22
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,318 @@
+// Package optimizer searches for the best values of scenario parameters
+// (e.g. the parameters of a strategy) with a genetic algorithm.
+package optimizer
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/scenario"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/stats"
+)
+
+// Genome is the index of the value of every gene (see Optimizer.Genes).
+type Genome []uint
+
+func (genome Genome) key() string {
+	return fmt.Sprint([]uint(genome))
+}
+
+// Individual is an evaluated genome.
+type Individual struct {
+	Genome Genome
+
+	// Values are the values of the genes.
+	Values []string
+
+	// Fitness is GrowthRate minus ExtinctionPenalty*ExtinctionRate.
+	Fitness float64
+
+	// GrowthRate is the mean growth rate (in percents) of the target
+	// population across the tries.
+	GrowthRate float64
+
+	// ExtinctionRate is the part of the tries where the target
+	// population died out (in percents).
+	ExtinctionRate float64
+
+	// Result is the result of the evaluation. It is kept only for
+	// the BestIndividualsToPrint fittest individuals evaluated so far
+	// and is nil for the rest, so that the memory does not grow with
+	// the amount of evaluated genomes.
+	Result *scenario.Result
+}
+
+// Generation is the summary of a single generation.
+type Generation struct {
+	Index       uint
+	Best        *Individual
+	MeanFitness float64
+}
+
+// Optimizer is a genetic algorithm: every generation the fittest
+// individuals survive (see Elite), and the rest of the population is
+// bred from tournament-selected parents by a uniform crossover and
+// a mutation.
+type Optimizer struct {
+	// Base is the scenario to evaluate the genomes in.
+	Base *scenario.Scenario
+
+	// Genes are the scenario parameters to optimize and the values
+	// they may take, e.g. "population.1.parameters.kindness=0:4:0.25".
+	Genes []scenario.SweepAxis
+
+	// Target is the index of the population in Base whose growth
+	// defines the fitness.
+	Target uint
+
+	PopulationSize uint
+	Generations    uint
+
+	// Elite is the amount of the fittest individuals which pass to
+	// the next generation unchanged.
+	Elite uint
+
+	// MutationRate is the probability of every gene of a bred
+	// individual to take a random value.
+	MutationRate float64
+
+	// ExtinctionPenalty is how many percents of the growth rate
+	// a 1% extinction rate costs.
+	ExtinctionPenalty float64
+
+	// Seed is the seed of the algorithm; it is also the master seed of
+	// every evaluation, so that all the genomes play the same worlds.
+	Seed int64
+
+	// Parallel is the amount of genomes evaluated simultaneously
+	// (the amount of CPUs if zero).
+	Parallel int
+}
+
+// Outcome is the result of an optimization.
+type Outcome struct {
+	Optimizer *Optimizer
+	History   []Generation
+
+	// Individuals are all the evaluated genomes, the fittest first.
+	Individuals []*Individual
+}
+
+// Best returns the fittest individual.
+func (outcome *Outcome) Best() *Individual {
+	return outcome.Individuals[0]
+}
+
+func (optimizer *Optimizer) validate() error {
+	if len(optimizer.Genes) == 0 {
+		return fmt.Errorf("no genes to optimize")
+	}
+	for _, gene := range optimizer.Genes {
+		if len(gene.Values) == 0 {
+			return fmt.Errorf("gene '%s' has no values", gene.Parameter)
+		}
+	}
+	if optimizer.Target >= uint(len(optimizer.Base.Population)) {
+		return fmt.Errorf("target population #%d does not exist: there are only %d populations",
+			optimizer.Target, len(optimizer.Base.Population))
+	}
+	if optimizer.PopulationSize < 2 {
+		return fmt.Errorf("the population size should be at least 2")
+	}
+	if optimizer.Generations == 0 {
+		return fmt.Errorf("the amount of generations should be positive")
+	}
+	if optimizer.Elite >= optimizer.PopulationSize {
+		return fmt.Errorf("the elite (%d) should be less than the population size (%d)",
+			optimizer.Elite, optimizer.PopulationSize)
+	}
+	if optimizer.MutationRate < 0 || optimizer.MutationRate > 1 {
+		return fmt.Errorf("the mutation rate should be within [0, 1], but it is %f", optimizer.MutationRate)
+	}
+	return nil
+}
+
+// Run evolves the population for the configured amount of generations.
+// The genomes already evaluated are not evaluated again.
+func (optimizer *Optimizer) Run() (*Outcome, error) {
+	if err := optimizer.validate(); err != nil {
+		return nil, err
+	}
+	rng := rand.New(rand.NewSource(optimizer.Seed))
+	evaluated := map[string]*Individual{}
+	outcome := &Outcome{
+		Optimizer: optimizer,
+	}
+
+	population := make([]Genome, 0, optimizer.PopulationSize)
+	for uint(len(population)) < optimizer.PopulationSize {
+		population = append(population, optimizer.randomGenome(rng))
+	}
+
+	for generationIdx := uint(0); generationIdx < optimizer.Generations; generationIdx++ {
+		individuals, err := optimizer.evaluate(population, evaluated)
+		if err != nil {
+			return nil, fmt.Errorf("generation #%d: %w", generationIdx, err)
+		}
+		sortByFitness(individuals)
+		releaseResults(evaluated)
+
+		fitnesses := make([]float64, 0, len(individuals))
+		for _, individual := range individuals {
+			fitnesses = append(fitnesses, individual.Fitness)
+		}
+		outcome.History = append(outcome.History, Generation{
+			Index:       generationIdx,
+			Best:        individuals[0],
+			MeanFitness: stats.Mean(fitnesses),
+		})
+
+		population = population[:0]
+		for _, individual := range individuals[:optimizer.Elite] {
+			population = append(population, individual.Genome)
+		}
+		for uint(len(population)) < optimizer.PopulationSize {
+			a, b := selectParent(individuals, rng), selectParent(individuals, rng)
+			population = append(population, optimizer.breed(a.Genome, b.Genome, rng))
+		}
+	}
+
+	for _, individual := range evaluated {
+		outcome.Individuals = append(outcome.Individuals, individual)
+	}
+	sortByFitness(outcome.Individuals)
+	return outcome, nil
+}
+
+func (optimizer *Optimizer) randomGenome(rng *rand.Rand) Genome {
+	genome := make(Genome, len(optimizer.Genes))
+	for geneIdx, gene := range optimizer.Genes {
+		genome[geneIdx] = uint(rng.Intn(len(gene.Values)))
+	}
+	return genome
+}
+
+// breed makes a child genome by a uniform crossover and a mutation.
+func (optimizer *Optimizer) breed(a, b Genome, rng *rand.Rand) Genome {
+	child := make(Genome, len(a))
+	for geneIdx := range child {
+		if rng.Intn(2) == 0 {
+			child[geneIdx] = a[geneIdx]
+		} else {
+			child[geneIdx] = b[geneIdx]
+		}
+		if rng.Float64() < optimizer.MutationRate {
+			child[geneIdx] = uint(rng.Intn(len(optimizer.Genes[geneIdx].Values)))
+		}
+	}
+	return child
+}
+
+// selectParent returns the fitter of two random individuals.
+func selectParent(individuals []*Individual, rng *rand.Rand) *Individual {
+	a, b := individuals[rng.Intn(len(individuals))], individuals[rng.Intn(len(individuals))]
+	if b.Fitness > a.Fitness {
+		return b
+	}
+	return a
+}
+
+func sortByFitness(individuals []*Individual) {
+	sort.SliceStable(individuals, func(i, j int) bool {
+		if individuals[i].Fitness != individuals[j].Fitness {
+			return individuals[i].Fitness > individuals[j].Fitness
+		}
+		return individuals[i].Genome.key() < individuals[j].Genome.key()
+	})
+}
+
+// releaseResults drops the results of all the evaluated individuals
+// except the BestIndividualsToPrint fittest ones (see Individual.Result).
+func releaseResults(evaluated map[string]*Individual) {
+	individuals := make([]*Individual, 0, len(evaluated))
+	for _, individual := range evaluated {
+		individuals = append(individuals, individual)
+	}
+	sortByFitness(individuals)
+	for idx := BestIndividualsToPrint; idx < len(individuals); idx++ {
+		individuals[idx].Result = nil
+	}
+}
+
+// evaluate returns the individuals of the genomes, running the scenarios
+// of the genomes which are not evaluated yet.
+func (optimizer *Optimizer) evaluate(population []Genome, evaluated map[string]*Individual) ([]*Individual, error) {
+	parallel := optimizer.Parallel
+	if parallel <= 0 {
+		parallel = runtime.NumCPU()
+	}
+
+	var toEvaluate []*Individual
+	for _, genome := range population {
+		key := genome.key()
+		if _, ok := evaluated[key]; ok {
+			continue
+		}
+		individual := &Individual{Genome: genome}
+		evaluated[key] = individual
+		toEvaluate = append(toEvaluate, individual)
+	}
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, parallel)
+	errs := make([]error, len(toEvaluate))
+	for idx, individual := range toEvaluate {
+		wg.Add(1)
+		go func(idx int, individual *Individual) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			errs[idx] = optimizer.evaluateIndividual(individual)
+		}(idx, individual)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]*Individual, 0, len(population))
+	for _, genome := range population {
+		result = append(result, evaluated[genome.key()])
+	}
+	return result, nil
+}
+
+func (optimizer *Optimizer) evaluateIndividual(individual *Individual) error {
+	s := optimizer.Base
+	for geneIdx, gene := range optimizer.Genes {
+		value := gene.Values[individual.Genome[geneIdx]]
+		individual.Values = append(individual.Values, value)
+		var err error
+		s, err = s.WithParameter(gene.Parameter, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := s.Run(scenario.RunOptions{Seed: optimizer.Seed})
+	if err != nil {
+		return fmt.Errorf("genome %s: %w", strings.Join(individual.Values, ","), err)
+	}
+	extinctions := 0
+	for _, tryResult := range result.Tries {
+		if tryResult.PopulationByStrategy[optimizer.Target] == 0 {
+			extinctions++
+		}
+	}
+	individual.Result = result
+	individual.GrowthRate = stats.Mean(result.GrowthRates(int(optimizer.Target)))
+	individual.ExtinctionRate = float64(extinctions) / float64(len(result.Tries)) * 100
+	individual.Fitness = individual.GrowthRate - optimizer.ExtinctionPenalty*individual.ExtinctionRate
+	return nil
+}
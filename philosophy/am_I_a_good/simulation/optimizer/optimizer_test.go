@@ -0,0 +1,179 @@
+package optimizer
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/scenario"
+)
+
+func testOptimizer(t *testing.T) *Optimizer {
+	base, err := scenario.Parse([]byte(`
+name: optimize
+tries: 3
+weeks: 30
+world:
+  enable_children: false
+  enable_aging: false
+  amount_of_portions: 15
+population:
+  - strategy: reciprocity
+    citizens: 10
+  - strategy: eat_the_rest
+    citizens: 10
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var genes []scenario.SweepAxis
+	for _, s := range []string{
+		"population.0.parameters.kindness=0:3:1",
+		"population.0.parameters.forgiveness=0:3:1",
+	} {
+		gene, err := scenario.ParseSweepAxis(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		genes = append(genes, gene)
+	}
+	return &Optimizer{
+		Base:              base,
+		Genes:             genes,
+		PopulationSize:    8,
+		Generations:       4,
+		Elite:             1,
+		MutationRate:      0.5,
+		ExtinctionPenalty: 0.1,
+		Seed:              1,
+		Parallel:          2,
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		modify func(optimizer *Optimizer)
+	}{
+		{"no genes", func(optimizer *Optimizer) { optimizer.Genes = nil }},
+		{"no values", func(optimizer *Optimizer) { optimizer.Genes[0].Values = nil }},
+		{"no target", func(optimizer *Optimizer) { optimizer.Target = 2 }},
+		{"small population", func(optimizer *Optimizer) { optimizer.PopulationSize = 1 }},
+		{"no generations", func(optimizer *Optimizer) { optimizer.Generations = 0 }},
+		{"only elite", func(optimizer *Optimizer) { optimizer.Elite = optimizer.PopulationSize }},
+		{"mutation rate", func(optimizer *Optimizer) { optimizer.MutationRate = 1.5 }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			optimizer := testOptimizer(t)
+			tc.modify(optimizer)
+			if _, err := optimizer.Run(); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestBreed(t *testing.T) {
+	optimizer := testOptimizer(t)
+	rng := rand.New(rand.NewSource(1))
+	a, b := Genome{0, 0}, Genome{3, 3}
+
+	optimizer.MutationRate = 0
+	for try := 0; try < 20; try++ {
+		for geneIdx, allele := range optimizer.breed(a, b, rng) {
+			if allele != a[geneIdx] && allele != b[geneIdx] {
+				t.Fatalf("gene #%d is %d, which is of none of the parents", geneIdx, allele)
+			}
+		}
+	}
+
+	optimizer.MutationRate = 1
+	seen := map[uint]bool{}
+	for try := 0; try < 100; try++ {
+		for _, allele := range optimizer.breed(a, b, rng) {
+			if allele >= uint(len(optimizer.Genes[0].Values)) {
+				t.Fatalf("an invalid allele %d", allele)
+			}
+			seen[allele] = true
+		}
+	}
+	if len(seen) != len(optimizer.Genes[0].Values) {
+		t.Errorf("the mutations produced only %d alleles", len(seen))
+	}
+}
+
+func TestRun(t *testing.T) {
+	outcome, err := testOptimizer(t).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcome.History) != 4 {
+		t.Fatalf("%d generations instead of 4", len(outcome.History))
+	}
+
+	// the elite survives, so the best fitness never decreases
+	for idx := 1; idx < len(outcome.History); idx++ {
+		if outcome.History[idx].Best.Fitness < outcome.History[idx-1].Best.Fitness {
+			t.Errorf("the best fitness decreased in generation #%d: %f < %f",
+				idx, outcome.History[idx].Best.Fitness, outcome.History[idx-1].Best.Fitness)
+		}
+	}
+
+	// every genome is evaluated once, the fittest first
+	seen := map[string]bool{}
+	for idx, individual := range outcome.Individuals {
+		key := individual.Genome.key()
+		if seen[key] {
+			t.Errorf("genome %s is evaluated twice", key)
+		}
+		seen[key] = true
+		if idx > 0 && individual.Fitness > outcome.Individuals[idx-1].Fitness {
+			t.Errorf("the individuals are not sorted by fitness")
+		}
+		if kept := individual.Result != nil; kept != (idx < BestIndividualsToPrint) {
+			t.Errorf("the result of the individual #%d is kept: %v", idx, kept)
+		}
+		if len(individual.Values) != 2 || individual.Values[0] != outcome.Optimizer.Genes[0].Values[individual.Genome[0]] {
+			t.Errorf("unexpected values %v of genome %s", individual.Values, key)
+		}
+	}
+	if outcome.Best() != outcome.Individuals[0] || outcome.History[3].Best.Fitness != outcome.Best().Fitness {
+		t.Errorf("the best individual is not the best of the last generation")
+	}
+
+	// the same seed gives the same outcome
+	again, err := testOptimizer(t).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(again.Best().Values, outcome.Best().Values) || again.Best().Fitness != outcome.Best().Fitness {
+		t.Errorf("the outcome differs with the same seed: %v and %v", again.Best().Values, outcome.Best().Values)
+	}
+
+	var buf bytes.Buffer
+	outcome.Print(&buf)
+	if !strings.Contains(buf.String(), "fitness history") || !strings.Contains(buf.String(), "eat_the_rest") {
+		t.Errorf("unexpected report:\n%s", buf.String())
+	}
+}
+
+func TestReleaseResults(t *testing.T) {
+	evaluated := map[string]*Individual{}
+	for idx := 0; idx < BestIndividualsToPrint+5; idx++ {
+		genome := Genome{uint(idx)}
+		evaluated[genome.key()] = &Individual{
+			Genome:  genome,
+			Fitness: float64(idx),
+			Result:  &scenario.Result{},
+		}
+	}
+	releaseResults(evaluated)
+	for _, individual := range evaluated {
+		kept := individual.Result != nil
+		if expected := individual.Fitness >= 5; kept != expected {
+			t.Errorf("the result of the individual of fitness %f is kept: %v", individual.Fitness, kept)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package optimizer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/stats"
+)
+
+// BestIndividualsToPrint is the amount of the fittest genomes printed
+// by Outcome.Print.
+const BestIndividualsToPrint = 10
+
+// Print writes the fitness history and the fittest genomes; for the
+// fittest genome it also writes the growth rates of all the populations
+// of the scenario, to compare the genome with its competitors.
+func (outcome *Outcome) Print(w io.Writer) {
+	optimizer := outcome.Optimizer
+	target := optimizer.Base.Population[optimizer.Target].Label()
+	fmt.Fprintf(w, "optimizing: %s\n", target)
+	fmt.Fprintf(w, "master seed: %d\n", optimizer.Seed)
+	fmt.Fprintf(w, "evaluated genomes: %d\n", len(outcome.Individuals))
+
+	fmt.Fprintf(w, "\nfitness history:\n")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "generation\tbest\tmean\tbest genome\t\n")
+	for _, generation := range outcome.History {
+		fmt.Fprintf(tw, "%d\t%.2f\t%.2f\t%s\t\n",
+			generation.Index, generation.Best.Fitness, generation.MeanFitness,
+			strings.Join(generation.Best.Values, ","))
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\nbest genomes:\n")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, gene := range optimizer.Genes {
+		fmt.Fprintf(tw, "%s\t", gene.Parameter)
+	}
+	fmt.Fprintf(tw, "fitness\tgrowth rate\textinction rate\t\n")
+	for idx, individual := range outcome.Individuals {
+		if idx >= BestIndividualsToPrint {
+			break
+		}
+		for _, value := range individual.Values {
+			fmt.Fprintf(tw, "%s\t", value)
+		}
+		fmt.Fprintf(tw, "%.2f\t%.2f%%\t%.2f%%\t\n",
+			individual.Fitness, individual.GrowthRate, individual.ExtinctionRate)
+	}
+	tw.Flush()
+
+	best := outcome.Best()
+	fmt.Fprintf(w, "\nthe best genome against the rest of the population:\n")
+	for strategyIdx, strategyResult := range best.Result.Strategies {
+		fmt.Fprintf(w, "\t%s: growth rate %.2f%%\n",
+			strategyResult.Name, stats.Mean(best.Result.GrowthRates(strategyIdx)))
+	}
+}
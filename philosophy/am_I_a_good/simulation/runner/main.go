@@ -0,0 +1,304 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	_ "net/http/pprof"
+	"os"
+	"strings"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/optimizer"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/scenario"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/strategy"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/timeseries"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/tournament"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/training"
+)
+
+func main() {
+	pprofAddr := flag.String("pprof", "", "the address to listen for pprof requests (e.g. 127.0.0.1:6060); disabled if empty")
+	seed := flag.Int64("seed", 0, "the master seed; overrides the seed of the scenario file (random if neither is set)")
+	replayTry := flag.Int("replay-try", -1, "run only the try with this index (use with -seed to reproduce it)")
+	seedReport := flag.Bool("seed-report", false, "print the seed and the outcome of every try")
+	timeSeriesPath := flag.String("timeseries", "", "write the state of every week of every try to this file")
+	timeSeriesFormat := flag.String("timeseries-format", "", "the format of the time series file: csv or jsonl (guessed by the extension if empty)")
+	var sweepAxes []scenario.SweepAxis
+	flag.Func("sweep", "sweep a scenario parameter: 'parameter=from:to:step' or 'parameter=v1,v2,...' (e.g. world.amount_of_portions=80:200:20); may be repeated to sweep over a grid", func(s string) error {
+		axis, err := scenario.ParseSweepAxis(s)
+		if err != nil {
+			return err
+		}
+		sweepAxes = append(sweepAxes, axis)
+		return nil
+	})
+	sweepParallel := flag.Int("sweep-parallel", 0, "the amount of sweep points (or genomes of -optimize) to run simultaneously (the amount of CPUs if zero)")
+	tournamentMode := flag.String("tournament", "", "instead of the scenario population, play a tournament: monoculture, one-vs-baseline, round-robin or all-in-one")
+	tournamentStrategies := flag.String("strategies", "", "comma-separated participants of the tournament (all known strategies if empty)")
+	tournamentBaseline := flag.String("baseline", "do_not_trust", "the baseline strategy of the one-vs-baseline tournament")
+	tournamentCitizens := flag.Uint("citizens", 0, "the initial amount of citizens per strategy in a tournament (the first population of the scenario if zero)")
+	var optimizeGenes []scenario.SweepAxis
+	flag.Func("optimize", "a gene of the genetic algorithm: 'parameter=from:to:step' or 'parameter=v1,v2,...' (e.g. population.0.parameters.kindness=0:4:0.5); may be repeated", func(s string) error {
+		gene, err := scenario.ParseSweepAxis(s)
+		if err != nil {
+			return err
+		}
+		optimizeGenes = append(optimizeGenes, gene)
+		return nil
+	})
+	optimizeTarget := flag.Uint("optimize-target", 0, "the index of the population whose growth rate is the fitness of the genetic algorithm")
+	optimizePopulation := flag.Uint("optimize-population", 20, "the amount of genomes in every generation of the genetic algorithm")
+	optimizeGenerations := flag.Uint("optimize-generations", 10, "the amount of generations of the genetic algorithm")
+	optimizeElite := flag.Uint("optimize-elite", 2, "the amount of the fittest genomes passed to the next generation unchanged")
+	optimizeMutationRate := flag.Float64("optimize-mutation-rate", 0.1, "the probability of a gene of a bred genome to take a random value")
+	optimizeExtinctionPenalty := flag.Float64("optimize-extinction-penalty", 0, "how many percents of the growth rate a 1% extinction rate of the target costs")
+	trainEpisodes := flag.Uint("train", 0, "before the run, train the q_learning population on this amount of single-try episodes")
+	qTablePath := flag.String("q-table", "", "the file to load the Q-table of the q_learning population from (if exists) and to save it to after -train")
+	listStrategies := flag.Bool("list-strategies", false, "print the registered strategies and exit")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [options] <scenario.yaml>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *listStrategies {
+		printStrategies(os.Stdout)
+		return
+	}
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *pprofAddr != "" {
+		go func() {
+			log.Fatal(http.ListenAndServe(*pprofAddr, nil))
+		}()
+	}
+
+	s, err := scenario.Load(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	masterSeed := s.MasterSeed()
+	if isFlagSet("seed") {
+		masterSeed = *seed
+	}
+
+	opts := scenario.RunOptions{
+		Seed: masterSeed,
+	}
+	if *replayTry >= 0 {
+		opts.OnlyTries = append(opts.OnlyTries, uint(*replayTry))
+	}
+
+	if *timeSeriesPath != "" {
+		var format timeseries.Format
+		if *timeSeriesFormat != "" {
+			format, err = timeseries.ParseFormat(*timeSeriesFormat)
+		} else {
+			format, err = timeseries.FormatFromPath(*timeSeriesPath)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		f, err := os.Create(*timeSeriesPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		opts.TimeSeries, err = timeseries.NewRecorder(f, format, s.StrategyNames())
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if (*trainEpisodes > 0 || *qTablePath != "") && (*tournamentMode != "" || len(sweepAxes) > 0 || len(optimizeGenes) > 0) {
+		log.Fatal("-train and -q-table cannot be combined with -tournament, -sweep or -optimize")
+	}
+
+	if *tournamentMode != "" {
+		if opts.TimeSeries != nil || len(sweepAxes) > 0 || len(optimizeGenes) > 0 {
+			log.Fatal("-tournament cannot be combined with -timeseries, -sweep or -optimize")
+		}
+		mode, err := tournament.ParseMode(*tournamentMode)
+		if err != nil {
+			log.Fatal(err)
+		}
+		t := &tournament.Tournament{
+			Base:     s,
+			Mode:     mode,
+			Baseline: *tournamentBaseline,
+			Citizens: *tournamentCitizens,
+		}
+		if *tournamentStrategies != "" {
+			t.Strategies = strings.Split(*tournamentStrategies, ",")
+		}
+		if t.Citizens == 0 {
+			t.Citizens = s.Population[0].Citizens
+		}
+		outcome, err := t.Run(opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		outcome.Print(os.Stdout)
+		return
+	}
+
+	if len(optimizeGenes) > 0 {
+		if opts.TimeSeries != nil || len(opts.OnlyTries) > 0 || len(sweepAxes) > 0 {
+			log.Fatal("-optimize cannot be combined with -timeseries, -replay-try or -sweep")
+		}
+		o := &optimizer.Optimizer{
+			Base:              s,
+			Genes:             optimizeGenes,
+			Target:            *optimizeTarget,
+			PopulationSize:    *optimizePopulation,
+			Generations:       *optimizeGenerations,
+			Elite:             *optimizeElite,
+			MutationRate:      *optimizeMutationRate,
+			ExtinctionPenalty: *optimizeExtinctionPenalty,
+			Seed:              masterSeed,
+			Parallel:          *sweepParallel,
+		}
+		outcome, err := o.Run()
+		if err != nil {
+			log.Fatal(err)
+		}
+		outcome.Print(os.Stdout)
+		return
+	}
+
+	if len(sweepAxes) > 0 {
+		if opts.TimeSeries != nil || len(opts.OnlyTries) > 0 {
+			log.Fatal("-sweep cannot be combined with -timeseries or -replay-try")
+		}
+		points, err := s.Sweep(sweepAxes, opts, *sweepParallel)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("master seed: %d\n", masterSeed)
+		scenario.PrintSweep(os.Stdout, sweepAxes, points)
+		return
+	}
+
+	if *trainEpisodes > 0 || *qTablePath != "" {
+		opts.Strategies, err = prepareQLearner(s, masterSeed, *trainEpisodes, *qTablePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	result, err := s.Run(opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	result.Print(os.Stdout)
+	if len(result.Tries) > 1 {
+		result.PrintStatistics(os.Stdout)
+	}
+	if *seedReport || *replayTry >= 0 {
+		result.PrintSeeds(os.Stdout)
+	}
+}
+
+// prepareQLearner loads the Q-table of the q_learning population,
+// trains it and returns the strategies to evaluate it with: the learner
+// neither learns nor explores anymore.
+func prepareQLearner(s *scenario.Scenario, masterSeed int64, episodes uint, qTablePath string) ([]engine.Strategy, error) {
+	target := -1
+	for idx, population := range s.Population {
+		if population.Strategy == "q_learning" {
+			target = idx
+			break
+		}
+	}
+	if target < 0 {
+		return nil, fmt.Errorf("there is no q_learning population to train")
+	}
+	instance, err := s.Population[target].NewStrategy()
+	if err != nil {
+		return nil, err
+	}
+	learner := instance.(*strategy.QLearner)
+
+	if qTablePath != "" {
+		f, err := os.Open(qTablePath)
+		switch {
+		case err == nil:
+			learner.Table, err = strategy.LoadQTable(f)
+			f.Close()
+			if err != nil {
+				return nil, fmt.Errorf("unable to load '%s': %w", qTablePath, err)
+			}
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, fmt.Errorf("unable to open '%s': %w", qTablePath, err)
+		}
+	}
+
+	if episodes > 0 {
+		trainer := &training.Trainer{
+			Base:     s,
+			Target:   uint(target),
+			Learner:  learner,
+			Episodes: episodes,
+			Seed:     masterSeed,
+		}
+		outcome, err := trainer.Run()
+		if err != nil {
+			return nil, err
+		}
+		outcome.Print(os.Stdout)
+		fmt.Println()
+		if qTablePath != "" {
+			f, err := os.Create(qTablePath)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create '%s': %w", qTablePath, err)
+			}
+			err = learner.Table.Save(f)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return nil, fmt.Errorf("unable to save '%s': %w", qTablePath, err)
+			}
+		}
+	}
+
+	learner.Training = false
+	learner.Exploration = 0
+	strategies := make([]engine.Strategy, target+1)
+	strategies[target] = learner
+	return strategies, nil
+}
+
+func printStrategies(w io.Writer) {
+	defaultWorld := engine.DefaultConfig()
+	for _, info := range strategy.All() {
+		fmt.Fprintf(w, "%s: %s", info.Name, info.Description)
+		if info.Suicidal {
+			fmt.Fprintf(w, " (requires allow_suicidal_strategies)")
+		}
+		if err := info.Supports(&defaultWorld); err != nil && !info.Suicidal {
+			fmt.Fprintf(w, " (%v)", errors.Unwrap(err))
+		}
+		fmt.Fprintf(w, "\n")
+		for _, param := range info.Parameters {
+			fmt.Fprintf(w, "\t%s (default %g): %s\n", param.Name, param.Default, param.Description)
+		}
+	}
+}
+
+func isFlagSet(name string) bool {
+	result := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			result = true
+		}
+	})
+	return result
+}
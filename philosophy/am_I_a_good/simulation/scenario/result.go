@@ -0,0 +1,136 @@
+package scenario
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+const flexibilityBuckets = 10
+
+// StrategyResult is the outcome of a single strategy summed across all tries.
+type StrategyResult struct {
+	Name            string
+	InitialCitizens uint
+	Survived        uint64
+
+	// Culture is how the citizens acquired the strategy, summed across
+	// all tries.
+	Culture engine.CultureStats
+}
+
+// GrowthRate returns the average change of the population in percents.
+func (strategyResult *StrategyResult) GrowthRate(tries uint) float64 {
+	return float64(strategyResult.Survived)/float64(tries)/float64(strategyResult.InitialCitizens)*100 - 100
+}
+
+// TryResult is the outcome of a single try.
+type TryResult struct {
+	Index uint
+	Seed  int64
+
+	// PopulationByStrategy is the final amount of citizens following
+	// each strategy, in the order of Result.Strategies.
+	PopulationByStrategy []uint
+}
+
+// Result is the outcome of a scenario.
+type Result struct {
+	Scenario *Scenario
+
+	// Seed is the master seed the tries seeds were derived from.
+	Seed int64
+
+	Strategies                      []StrategyResult
+	Tries                           []TryResult
+	PopulationByFlexibility         [flexibilityBuckets]uint
+	PopulationByFlexibilitySurvived [flexibilityBuckets]uint
+	NoPopulation                    uint
+
+	// Orphans and OrphanGraduations are summed across all tries
+	// (see engine.Config.OrphanPolicy).
+	Orphans           uint64
+	OrphanGraduations uint64
+
+	// GenePool is the amount of the survivors by genotype (see
+	// engine.Genotype), summed across all tries.
+	GenePool map[string]uint64
+}
+
+// genePoolTop is the amount of the most common genotypes printed.
+const genePoolTop = 10
+
+// Print writes a human-readable report.
+func (result *Result) Print(w io.Writer) {
+	tries := uint(len(result.Tries))
+	if result.Scenario.Name != "" {
+		fmt.Fprintf(w, "scenario: %s\n", result.Scenario.Name)
+	}
+	fmt.Fprintf(w, "master seed: %d\n", result.Seed)
+
+	for _, strategyResult := range result.Strategies {
+		fmt.Fprintf(w, "strategy %s: sum of survived in %d tries: %d. Growth rate: %.2f%%\n",
+			strategyResult.Name, tries, strategyResult.Survived, strategyResult.GrowthRate(tries))
+	}
+
+	if result.Scenario.World.ChangeStrategyExponent > 0 {
+		fmt.Fprintf(w, "transmission model: %s\n", result.Scenario.World.TransmissionModel)
+		for idx, survived := range result.PopulationByFlexibilitySurvived {
+			if result.PopulationByFlexibility[idx] == 0 {
+				continue
+			}
+			fmt.Fprintf(w, "change strategy rate range [%.1f-%.1f): Growth rate: %.2f%%\n",
+				float64(idx)/flexibilityBuckets, float64(idx+1)/flexibilityBuckets,
+				float64(survived)/float64(result.PopulationByFlexibility[idx])*100-100)
+		}
+	}
+
+	if result.Scenario.World.EnableChildren {
+		for _, strategyResult := range result.Strategies {
+			culture := &strategyResult.Culture
+			fmt.Fprintf(w, "strategy %s acquired: from %s %d, from %s %d, from %s %d; converted to %d, from %d\n",
+				strategyResult.Name,
+				engine.LearningChannelParent, culture.Learned[engine.LearningChannelParent],
+				engine.LearningChannelPeers, culture.Learned[engine.LearningChannelPeers],
+				engine.LearningChannelInstitutions, culture.Learned[engine.LearningChannelInstitutions],
+				culture.ConvertedTo, culture.ConvertedFrom)
+		}
+	}
+
+	fmt.Fprintf(w, "genocide rate: %.2f%%\n", float64(result.NoPopulation)/float64(tries)*100)
+
+	if len(result.GenePool) > 0 {
+		genotypes := make([]string, 0, len(result.GenePool))
+		for genotype := range result.GenePool {
+			genotypes = append(genotypes, genotype)
+		}
+		sort.Slice(genotypes, func(i, j int) bool {
+			if result.GenePool[genotypes[i]] != result.GenePool[genotypes[j]] {
+				return result.GenePool[genotypes[i]] > result.GenePool[genotypes[j]]
+			}
+			return genotypes[i] < genotypes[j]
+		})
+		fmt.Fprintf(w, "gene pool: %d genotypes survived, the most common ones:\n", len(genotypes))
+		if len(genotypes) > genePoolTop {
+			genotypes = genotypes[:genePoolTop]
+		}
+		for _, genotype := range genotypes {
+			fmt.Fprintf(w, "\t%d\t%s\n", result.GenePool[genotype], genotype)
+		}
+	}
+
+	if result.Orphans > 0 {
+		fmt.Fprintf(w, "orphans (%s): %d, graduated: %.2f%%\n", result.Scenario.World.OrphanPolicy,
+			result.Orphans, float64(result.OrphanGraduations)/float64(result.Orphans)*100)
+	}
+}
+
+// PrintSeeds writes the seed and the final population of every try.
+func (result *Result) PrintSeeds(w io.Writer) {
+	for _, tryResult := range result.Tries {
+		fmt.Fprintf(w, "try #%d: seed %d, population by strategy %v\n",
+			tryResult.Index, tryResult.Seed, tryResult.PopulationByStrategy)
+	}
+}
@@ -0,0 +1,157 @@
+package scenario
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/timeseries"
+)
+
+// MasterSeed returns the seed defined in the scenario or a new random one.
+func (scenario *Scenario) MasterSeed() int64 {
+	if scenario.Seed != nil {
+		return *scenario.Seed
+	}
+	return engine.NewMasterSeed()
+}
+
+// RunOptions are the parameters of a run which are not a part of the scenario.
+type RunOptions struct {
+	// Seed is the master seed.
+	Seed int64
+
+	// OnlyTries restricts the run to these tries; combined with
+	// the same Seed this reproduces them exactly.
+	OnlyTries []uint
+
+	// TimeSeries if set receives the state of every week of every try.
+	TimeSeries *timeseries.Recorder
+
+	// Strategies if set replaces the strategies of the populations with
+	// the same indexes (nil items are ignored), e.g. to play a trained
+	// strategy instance.
+	Strategies []engine.Strategy
+}
+
+// Run executes the tries of the scenario and collects the results.
+func (scenario *Scenario) Run(opts RunOptions) (*Result, error) {
+	if len(opts.Strategies) > len(scenario.Population) {
+		return nil, fmt.Errorf("%d strategies are given for %d populations", len(opts.Strategies), len(scenario.Population))
+	}
+	for _, tryIdx := range opts.OnlyTries {
+		if tryIdx >= scenario.Tries {
+			return nil, fmt.Errorf("try #%d does not exist: there are only %d tries", tryIdx, scenario.Tries)
+		}
+	}
+
+	strategies := make([]engine.Strategy, len(scenario.Population))
+	result := &Result{
+		Scenario:   scenario,
+		Seed:       opts.Seed,
+		Strategies: make([]StrategyResult, len(scenario.Population)),
+	}
+	for idx, population := range scenario.Population {
+		if idx < len(opts.Strategies) && opts.Strategies[idx] != nil {
+			strategies[idx] = opts.Strategies[idx]
+		} else {
+			s, err := population.NewStrategy()
+			if err != nil {
+				return nil, fmt.Errorf("population #%d: %w", idx+1, err)
+			}
+			strategies[idx] = s
+		}
+		result.Strategies[idx] = StrategyResult{
+			Name:            population.Label(),
+			InitialCitizens: population.Citizens,
+		}
+	}
+
+	experiment := &engine.Experiment{
+		Config:    scenario.World,
+		Tries:     scenario.Tries,
+		Weeks:     scenario.Weeks,
+		Seed:      opts.Seed,
+		OnlyTries: opts.OnlyTries,
+		Populate: func(playground *engine.Playground) {
+			for idx, population := range scenario.Population {
+				playground.AddCitizens(strategies[idx], population.Citizens)
+			}
+		},
+		OnStart: func(tryIdx uint, playground *engine.Playground) {
+			for _, citizen := range playground.Citizens {
+				result.PopulationByFlexibility[flexibilityBucket(citizen)]++
+			}
+		},
+		OnWeek: func(tryIdx uint, week uint, playground *engine.Playground) {
+			if opts.TimeSeries == nil {
+				return
+			}
+			opts.TimeSeries.Record(tryIdx, week, playground, populationByStrategy(strategies, playground))
+		},
+		OnFinish: func(tryIdx uint, playground *engine.Playground) {
+			if opts.TimeSeries != nil {
+				opts.TimeSeries.FinishTry(tryIdx)
+			}
+			tryResult := TryResult{
+				Index:                tryIdx,
+				Seed:                 playground.Seed,
+				PopulationByStrategy: populationByStrategy(strategies, playground),
+			}
+			for strategyIdx, population := range tryResult.PopulationByStrategy {
+				result.Strategies[strategyIdx].Survived += uint64(population)
+			}
+			for strategyIdx, strategy := range strategies {
+				result.Strategies[strategyIdx].Culture.Add(playground.CultureStats(strategy))
+			}
+			for _, citizen := range playground.Citizens {
+				result.PopulationByFlexibilitySurvived[flexibilityBucket(citizen)]++
+				if _, ok := citizen.Strategy.(engine.Genotype); ok {
+					if result.GenePool == nil {
+						result.GenePool = map[string]uint64{}
+					}
+					result.GenePool[fmt.Sprint(citizen.Strategy)]++
+				}
+			}
+			if len(playground.Citizens) == 0 {
+				result.NoPopulation++
+			}
+			totalStats := playground.TotalStats()
+			result.Orphans += uint64(totalStats.Orphans)
+			result.OrphanGraduations += uint64(totalStats.OrphanGraduations)
+			result.Tries = append(result.Tries, tryResult)
+		},
+	}
+	experiment.Run()
+	if opts.TimeSeries != nil {
+		if err := opts.TimeSeries.Err(); err != nil {
+			return nil, fmt.Errorf("unable to record the time series: %w", err)
+		}
+	}
+
+	sort.Slice(result.Tries, func(i, j int) bool {
+		return result.Tries[i].Index < result.Tries[j].Index
+	})
+	return result, nil
+}
+
+func populationByStrategy(strategies []engine.Strategy, playground *engine.Playground) []uint {
+	result := make([]uint, len(strategies))
+	for _, citizen := range playground.Citizens {
+		for strategyIdx, strategy := range strategies {
+			if strategy != citizen.Strategy {
+				continue
+			}
+			result[strategyIdx]++
+		}
+	}
+	return result
+}
+
+func flexibilityBucket(citizen *engine.Citizen) uint {
+	bucket := uint(citizen.ChangeStrategyProbability * flexibilityBuckets)
+	if bucket >= flexibilityBuckets {
+		bucket = flexibilityBuckets - 1
+	}
+	return bucket
+}
@@ -0,0 +1,72 @@
+package scenario
+
+import (
+	"reflect"
+	"testing"
+)
+
+const replayScenario = `
+name: replay
+tries: 6
+weeks: 60
+population:
+  - strategy: trust_kind_mirror
+    citizens: 30
+  - strategy: eat_the_rest
+    citizens: 30
+`
+
+func TestRunReplaysTries(t *testing.T) {
+	s, err := Parse([]byte(replayScenario))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const masterSeed = 7
+	full, err := s.Run(RunOptions{Seed: masterSeed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(full.Tries) != int(s.Tries) {
+		t.Fatalf("%d tries are run instead of %d", len(full.Tries), s.Tries)
+	}
+	if reflect.DeepEqual(full.Tries[0].PopulationByStrategy, full.Tries[1].PopulationByStrategy) &&
+		reflect.DeepEqual(full.Tries[1].PopulationByStrategy, full.Tries[2].PopulationByStrategy) {
+		t.Fatalf("the tries do not differ, so the replay check is meaningless: %+v", full.Tries)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		onlyTries []uint
+	}{
+		{name: "first", onlyTries: []uint{0}},
+		{name: "middle", onlyTries: []uint{3}},
+		{name: "last", onlyTries: []uint{5}},
+		{name: "several", onlyTries: []uint{4, 1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			replay, err := s.Run(RunOptions{Seed: masterSeed, OnlyTries: tc.onlyTries})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(replay.Tries) != len(tc.onlyTries) {
+				t.Fatalf("%d tries are replayed instead of %d", len(replay.Tries), len(tc.onlyTries))
+			}
+			for _, tryResult := range replay.Tries {
+				expected := full.Tries[tryResult.Index]
+				if !reflect.DeepEqual(tryResult, expected) {
+					t.Errorf("the replayed try %+v differs from the try of the full run %+v", tryResult, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestRunInvalidTry(t *testing.T) {
+	s, err := Parse([]byte(replayScenario))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Run(RunOptions{OnlyTries: []uint{s.Tries}}); err == nil {
+		t.Errorf("expected an error for a try out of range")
+	}
+}
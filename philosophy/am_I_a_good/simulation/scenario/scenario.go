@@ -0,0 +1,149 @@
+package scenario
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/strategy"
+)
+
+// Population is an initial group of citizens following the same strategy.
+type Population struct {
+	// Name is the label of the population in reports (see Label).
+	Name string `yaml:"name,omitempty"`
+
+	// Strategy is the name of a registered strategy (see strategy.Register).
+	Strategy string `yaml:"strategy"`
+
+	// Parameters of the strategy; the omitted ones take default values.
+	Parameters strategy.Parameters `yaml:"parameters,omitempty"`
+
+	Citizens uint `yaml:"citizens"`
+}
+
+// Label returns the name of the population if it is set, otherwise
+// the name of the strategy and its parameters,
+// e.g. "reciprocity(kindness=2)".
+func (population *Population) Label() string {
+	if population.Name != "" {
+		return population.Name
+	}
+	if len(population.Parameters) == 0 {
+		return population.Strategy
+	}
+	return population.Strategy + "(" + population.Parameters.String() + ")"
+}
+
+// NewStrategy returns a new instance of the strategy of the population.
+func (population *Population) NewStrategy() (engine.Strategy, error) {
+	return strategy.New(population.Strategy, population.Parameters)
+}
+
+// Scenario is a declarative description of an experiment.
+type Scenario struct {
+	Name  string `yaml:"name"`
+	Tries uint   `yaml:"tries"`
+	Weeks uint   `yaml:"weeks"`
+
+	// Seed is the master seed of the scenario; a random one is used
+	// if it is not set (see engine.TrySeed).
+	Seed *int64 `yaml:"seed"`
+
+	World      engine.Config `yaml:"world"`
+	Population []Population  `yaml:"population"`
+}
+
+// Load reads and validates a scenario file. The fields missing in
+// the "world" section are taken from engine.DefaultConfig.
+func Load(path string) (*Scenario, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read '%s': %w", path, err)
+	}
+	scenario, err := Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load '%s': %w", path, err)
+	}
+	return scenario, nil
+}
+
+// Parse parses and validates a scenario.
+func Parse(b []byte) (*Scenario, error) {
+	scenario := &Scenario{
+		World: engine.DefaultConfig(),
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(scenario); err != nil {
+		return nil, fmt.Errorf("unable to parse: %w", err)
+	}
+	if err := scenario.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario: %w", err)
+	}
+	return scenario, nil
+}
+
+// Validate returns an error if the scenario cannot be run.
+func (scenario *Scenario) Validate() error {
+	if scenario.Tries == 0 {
+		return fmt.Errorf("tries should be positive")
+	}
+	if scenario.Weeks == 0 {
+		return fmt.Errorf("weeks should be positive")
+	}
+	if err := scenario.World.Validate(); err != nil {
+		return fmt.Errorf("invalid world: %w", err)
+	}
+	if len(scenario.Population) == 0 {
+		return fmt.Errorf("population is empty")
+	}
+	alreadySeen := map[string]bool{}
+	genotypes := map[string]int{}
+	for idx, population := range scenario.Population {
+		populationStrategy, err := population.NewStrategy()
+		if err != nil {
+			return fmt.Errorf("population #%d: %w", idx+1, err)
+		}
+		if genotype, ok := populationStrategy.(engine.Genotype); ok {
+			// the citizens with equal genes share the strategy on
+			// a playground, so the populations could not be told apart
+			key := engine.GenotypeKey(genotype)
+			if otherIdx, ok := genotypes[key]; ok {
+				return fmt.Errorf("population #%d: has the same genes %s as population #%d, merge them",
+					idx+1, key, otherIdx+1)
+			}
+			genotypes[key] = idx
+		}
+		info, err := strategy.Get(population.Strategy)
+		if err != nil {
+			return fmt.Errorf("population #%d: %w", idx+1, err)
+		}
+		if err := info.Supports(&scenario.World); err != nil {
+			return fmt.Errorf("population #%d: %w", idx+1, err)
+		}
+		label := population.Label()
+		if alreadySeen[label] {
+			return fmt.Errorf("population #%d: '%s' is already used by another population, set a different name",
+				idx+1, label)
+		}
+		alreadySeen[label] = true
+		if population.Citizens == 0 {
+			return fmt.Errorf("population #%d ('%s'): citizens should be positive", idx+1, label)
+		}
+	}
+	return nil
+}
+
+// StrategyNames returns the labels of the populations (see
+// Population.Label) in the order of the Population.
+func (scenario *Scenario) StrategyNames() []string {
+	result := make([]string, 0, len(scenario.Population))
+	for _, population := range scenario.Population {
+		result = append(result, population.Label())
+	}
+	return result
+}
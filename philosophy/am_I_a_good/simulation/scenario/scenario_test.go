@@ -0,0 +1,254 @@
+package scenario
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/strategy"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		yaml  string
+		error string
+	}{
+		{
+			name: "valid",
+			yaml: `
+name: valid
+tries: 2
+weeks: 3
+population:
+  - strategy: eat_the_rest
+    citizens: 10
+`,
+		},
+		{
+			name: "unknown field",
+			yaml: `
+name: unknown field
+tries: 2
+weeks: 3
+world:
+  amount_of_potions: 10
+population:
+  - strategy: eat_the_rest
+    citizens: 10
+`,
+			error: "amount_of_potions",
+		},
+		{
+			name: "no tries",
+			yaml: `
+weeks: 3
+population:
+  - strategy: eat_the_rest
+    citizens: 10
+`,
+			error: "tries should be positive",
+		},
+		{
+			name: "no weeks",
+			yaml: `
+tries: 2
+population:
+  - strategy: eat_the_rest
+    citizens: 10
+`,
+			error: "weeks should be positive",
+		},
+		{
+			name: "no population",
+			yaml: `
+tries: 2
+weeks: 3
+`,
+			error: "population is empty",
+		},
+		{
+			name: "unknown strategy",
+			yaml: `
+tries: 2
+weeks: 3
+population:
+  - strategy: eat_everybody
+    citizens: 10
+`,
+			error: "unknown strategy 'eat_everybody'",
+		},
+		{
+			name: "duplicate strategy",
+			yaml: `
+tries: 2
+weeks: 3
+population:
+  - strategy: eat_the_rest
+    citizens: 10
+  - strategy: eat_the_rest
+    citizens: 20
+`,
+			error: "is already used",
+		},
+		{
+			name: "named populations of the same strategy",
+			yaml: `
+tries: 2
+weeks: 3
+population:
+  - strategy: eat_the_rest
+    citizens: 10
+  - name: eat_the_rest_again
+    strategy: eat_the_rest
+    citizens: 20
+`,
+		},
+		{
+			name: "unknown parameter",
+			yaml: `
+tries: 2
+weeks: 3
+population:
+  - strategy: eat_the_rest
+    parameters:
+      kindness: 2
+    citizens: 10
+`,
+			error: "has no parameter 'kindness'",
+		},
+		{
+			name: "suicidal strategy",
+			yaml: `
+tries: 2
+weeks: 3
+population:
+  - strategy: share_everything
+    citizens: 10
+`,
+			error: "requires allow_suicidal_strategies",
+		},
+		{
+			name: "allowed suicidal strategy",
+			yaml: `
+tries: 2
+weeks: 3
+world:
+  allow_suicidal_strategies: true
+population:
+  - strategy: share_everything
+    citizens: 10
+`,
+		},
+		{
+			name: "no citizens",
+			yaml: `
+tries: 2
+weeks: 3
+population:
+  - strategy: eat_the_rest
+`,
+			error: "citizens should be positive",
+		},
+		{
+			name: "same genes",
+			yaml: `
+tries: 2
+weeks: 3
+population:
+  - strategy: genotype
+    name: kind
+    citizens: 10
+  - strategy: genotype
+    name: also_kind
+    citizens: 10
+    parameters:
+      altruism: 5
+`,
+			error: "has the same genes",
+		},
+		{
+			name: "different genes",
+			yaml: `
+tries: 2
+weeks: 3
+population:
+  - strategy: genotype
+    name: kind
+    citizens: 10
+  - strategy: genotype
+    name: greedy
+    citizens: 10
+    parameters:
+      altruism: 0
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.yaml))
+			switch {
+			case tc.error == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tc.error != "" && err == nil:
+				t.Errorf("expected an error containing '%s'", tc.error)
+			case tc.error != "" && !strings.Contains(err.Error(), tc.error):
+				t.Errorf("expected an error containing '%s', got: %v", tc.error, err)
+			}
+		})
+	}
+}
+
+func TestPopulationLabel(t *testing.T) {
+	for _, tc := range []struct {
+		population Population
+		label      string
+	}{
+		{population: Population{Strategy: "reciprocity"}, label: "reciprocity"},
+		{population: Population{Strategy: "reciprocity", Parameters: strategy.Parameters{"kindness": 2, "anger": 1}},
+			label: "reciprocity(anger=1,kindness=2)"},
+		{population: Population{Name: "kind", Strategy: "reciprocity", Parameters: strategy.Parameters{"kindness": 2}},
+			label: "kind"},
+	} {
+		if label := tc.population.Label(); label != tc.label {
+			t.Errorf("label '%s', expected '%s'", label, tc.label)
+		}
+	}
+}
+
+func TestParseDefaults(t *testing.T) {
+	s, err := Parse([]byte(`
+tries: 2
+weeks: 3
+world:
+  portion_energy: 2000
+population:
+  - strategy: eat_the_rest
+    citizens: 10
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := engine.DefaultConfig()
+	expected.PortionEnergy = 2000
+	if s.World != expected {
+		t.Errorf("world %+v, expected the defaults with the portion energy overridden: %+v", s.World, expected)
+	}
+}
+
+func TestLoadScenarios(t *testing.T) {
+	paths, err := filepath.Glob("../scenarios/*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no scenarios found")
+	}
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			if _, err := Load(path); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
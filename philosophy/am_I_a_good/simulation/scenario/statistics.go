@@ -0,0 +1,126 @@
+package scenario
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/stats"
+)
+
+// Comparison is the result of a significance test between two strategies.
+type Comparison struct {
+	StrategyA string
+	StrategyB string
+
+	// MeanDifference is the mean of (growth rate of A - growth rate of B)
+	// across tries, in percents.
+	MeanDifference float64
+	PValue         float64
+
+	// AdjustedPValue is PValue adjusted for all the comparisons of
+	// the Statistics (see stats.HolmBonferroni).
+	AdjustedPValue float64
+}
+
+// IsSignificant returns false if the strategies are statistically
+// indistinguishable at the level alpha (after the adjustment for
+// multiple comparisons).
+func (comparison *Comparison) IsSignificant(alpha float64) bool {
+	return comparison.AdjustedPValue < alpha
+}
+
+// Statistics describes the growth rates of the strategies across tries.
+type Statistics struct {
+	Strategies  []stats.Summary
+	Comparisons []Comparison
+}
+
+// GrowthRates returns the growth rate (in percents) of the strategy
+// in every try.
+func (result *Result) GrowthRates(strategyIdx int) []float64 {
+	initial := float64(result.Strategies[strategyIdx].InitialCitizens)
+	growthRates := make([]float64, len(result.Tries))
+	for tryIdx, tryResult := range result.Tries {
+		growthRates[tryIdx] = float64(tryResult.PopulationByStrategy[strategyIdx])/initial*100 - 100
+	}
+	return growthRates
+}
+
+// Statistics calculates the per-strategy summaries and the pairwise
+// significance tests (adjusted for multiple comparisons by
+// the Holm-Bonferroni method). The resampling is seeded by the master seed, so
+// the statistics are reproducible as well.
+func (result *Result) Statistics() *Statistics {
+	rng := rand.New(rand.NewSource(result.Seed))
+
+	growthRates := make([][]float64, len(result.Strategies))
+	statistics := &Statistics{}
+	for strategyIdx := range result.Strategies {
+		growthRates[strategyIdx] = result.GrowthRates(strategyIdx)
+		statistics.Strategies = append(statistics.Strategies, stats.Summarize(growthRates[strategyIdx], rng))
+	}
+
+	names := make([]string, len(result.Strategies))
+	for strategyIdx, strategyResult := range result.Strategies {
+		names[strategyIdx] = strategyResult.Name
+	}
+	statistics.Comparisons = Compare(names, growthRates, rng)
+	return statistics
+}
+
+// Compare runs the paired significance test between every pair of
+// the growth rate series (paired by the try index) and adjusts
+// the p-values for multiple comparisons by the Holm-Bonferroni method.
+func Compare(names []string, growthRates [][]float64, rng *rand.Rand) []Comparison {
+	var comparisons []Comparison
+	for idxA := range names {
+		for idxB := idxA + 1; idxB < len(names); idxB++ {
+			comparisons = append(comparisons, Comparison{
+				StrategyA:      names[idxA],
+				StrategyB:      names[idxB],
+				MeanDifference: stats.Mean(growthRates[idxA]) - stats.Mean(growthRates[idxB]),
+				PValue: stats.PairedPermutationTest(
+					growthRates[idxA], growthRates[idxB],
+					stats.DefaultResamples, rng,
+				),
+			})
+		}
+	}
+	pValues := make([]float64, len(comparisons))
+	for idx, comparison := range comparisons {
+		pValues[idx] = comparison.PValue
+	}
+	for idx, adjusted := range stats.HolmBonferroni(pValues) {
+		comparisons[idx].AdjustedPValue = adjusted
+	}
+	return comparisons
+}
+
+// PrintStatistics writes the per-strategy summaries and the pairwise
+// comparisons.
+func (result *Result) PrintStatistics(w io.Writer) {
+	if len(result.Tries) < 2 {
+		fmt.Fprintf(w, "statistics: not enough tries\n")
+		return
+	}
+	statistics := result.Statistics()
+	for strategyIdx, summary := range statistics.Strategies {
+		fmt.Fprintf(w, "strategy %s: growth rate per try: mean %.2f%%, std dev %.2f%%, %.0f%% CI [%.2f%%, %.2f%%]\n",
+			result.Strategies[strategyIdx].Name, summary.Mean, summary.StdDev,
+			stats.DefaultConfidence*100, summary.CILow, summary.CIHigh)
+	}
+	if len(statistics.Comparisons) > 0 {
+		fmt.Fprintf(w, "pairwise comparisons: paired permutation tests, p-values adjusted for %d comparisons by Holm-Bonferroni, alpha %.2f\n",
+			len(statistics.Comparisons), stats.DefaultAlpha)
+	}
+	for _, comparison := range statistics.Comparisons {
+		verdict := "significant"
+		if !comparison.IsSignificant(stats.DefaultAlpha) {
+			verdict = "statistically indistinguishable"
+		}
+		fmt.Fprintf(w, "%s vs %s: mean difference %+.2f%%, p-value %.4f (adjusted %.4f): %s\n",
+			comparison.StrategyA, comparison.StrategyB,
+			comparison.MeanDifference, comparison.PValue, comparison.AdjustedPValue, verdict)
+	}
+}
@@ -0,0 +1,316 @@
+package scenario
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/stats"
+)
+
+// SweepAxis is a scenario parameter and the values it takes in a sweep.
+type SweepAxis struct {
+	// Parameter is a dot-separated path within the scenario file, e.g.
+	// "world.amount_of_portions" or "population.1.citizens";
+	// "*" matches every item of a list, e.g. "population.*.citizens".
+	Parameter string
+
+	Values []string
+}
+
+// ParseSweepAxis parses "parameter=from:to:step" or "parameter=v1,v2,...".
+func ParseSweepAxis(s string) (SweepAxis, error) {
+	parameter, values, ok := strings.Cut(s, "=")
+	if !ok || parameter == "" || values == "" {
+		return SweepAxis{}, fmt.Errorf("invalid sweep '%s', expected 'parameter=from:to:step' or 'parameter=v1,v2,...'", s)
+	}
+	axis := SweepAxis{Parameter: parameter}
+
+	if !strings.Contains(values, ":") {
+		axis.Values = strings.Split(values, ",")
+		return axis, nil
+	}
+
+	words := strings.Split(values, ":")
+	if len(words) != 3 {
+		return SweepAxis{}, fmt.Errorf("invalid range '%s', expected 'from:to:step'", values)
+	}
+	var bounds [3]float64
+	for idx, word := range words {
+		v, err := strconv.ParseFloat(word, 64)
+		if err != nil {
+			return SweepAxis{}, fmt.Errorf("invalid range '%s': %w", values, err)
+		}
+		bounds[idx] = v
+	}
+	from, to, step := bounds[0], bounds[1], bounds[2]
+	if step <= 0 || to < from {
+		return SweepAxis{}, fmt.Errorf("invalid range '%s': expected from <= to and a positive step", values)
+	}
+	for idx := 0; ; idx++ {
+		v := from + float64(idx)*step
+		if v > to+step*1e-9 {
+			break
+		}
+		axis.Values = append(axis.Values, strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	return axis, nil
+}
+
+// SweepPoint is the outcome of the scenario at a single point of the grid.
+type SweepPoint struct {
+	// Values are the values of the axes, in the order of the axes.
+	Values []string
+	Result *Result
+
+	// Winner is the index of the strategy with the highest mean growth rate.
+	Winner int
+
+	// RunnerUp is the index of the second best strategy, or -1.
+	RunnerUp int
+
+	// PValue is the p-value of the comparison between the winner and the
+	// runner-up.
+	PValue float64
+}
+
+// IsTie returns true if the winner is statistically indistinguishable
+// from the runner-up.
+func (point *SweepPoint) IsTie() bool {
+	return point.RunnerUp >= 0 && !(point.PValue < stats.DefaultAlpha)
+}
+
+// WithParameter returns a copy of the scenario with the parameter
+// set to the value.
+func (scenario *Scenario) WithParameter(parameter string, value string) (*Scenario, error) {
+	b, err := yaml.Marshal(scenario)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize the scenario: %w", err)
+	}
+	var tree map[string]any
+	if err := yaml.Unmarshal(b, &tree); err != nil {
+		return nil, fmt.Errorf("unable to deserialize the scenario: %w", err)
+	}
+	var parsedValue any
+	if err := yaml.Unmarshal([]byte(value), &parsedValue); err != nil {
+		return nil, fmt.Errorf("unable to parse value '%s': %w", value, err)
+	}
+	if err := setParameter(tree, strings.Split(parameter, "."), parsedValue); err != nil {
+		return nil, fmt.Errorf("unable to set '%s': %w", parameter, err)
+	}
+	b, err = yaml.Marshal(tree)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize the scenario: %w", err)
+	}
+	result, err := Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s=%s: %w", parameter, value, err)
+	}
+	return result, nil
+}
+
+func setParameter(node any, path []string, value any) error {
+	key := path[0]
+	switch node := node.(type) {
+	case map[string]any:
+		if len(path) == 1 {
+			node[key] = value
+			return nil
+		}
+		child, ok := node[key]
+		if !ok || child == nil {
+			// an omitted section, e.g. "parameters" of a population
+			child = map[string]any{}
+			node[key] = child
+		}
+		return setParameter(child, path[1:], value)
+	case []any:
+		var indexes []int
+		if key == "*" {
+			for idx := range node {
+				indexes = append(indexes, idx)
+			}
+		} else {
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return fmt.Errorf("invalid index '%s' in a list of %d items", key, len(node))
+			}
+			indexes = append(indexes, idx)
+		}
+		for _, idx := range indexes {
+			if len(path) == 1 {
+				node[idx] = value
+				continue
+			}
+			if err := setParameter(node[idx], path[1:], value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("'%s' is not a section", key)
+}
+
+// Sweep runs the scenario at every point of the grid defined by the axes.
+// Up to parallel points are run simultaneously (runtime.NumCPU() if
+// parallel is zero); the tries of each point are run in parallel as well.
+// All points use the same seed, so they differ only by the parameters.
+func (scenario *Scenario) Sweep(axes []SweepAxis, opts RunOptions, parallel int) ([]SweepPoint, error) {
+	if len(axes) == 0 {
+		return nil, fmt.Errorf("no sweep axes")
+	}
+	if parallel <= 0 {
+		parallel = runtime.NumCPU()
+	}
+
+	// building the grid
+	points := []SweepPoint{{}}
+	scenarios := []*Scenario{scenario}
+	for _, axis := range axes {
+		if len(axis.Values) == 0 {
+			return nil, fmt.Errorf("sweep axis '%s' has no values", axis.Parameter)
+		}
+		var nextPoints []SweepPoint
+		var nextScenarios []*Scenario
+		for pointIdx, point := range points {
+			for _, value := range axis.Values {
+				pointScenario, err := scenarios[pointIdx].WithParameter(axis.Parameter, value)
+				if err != nil {
+					return nil, err
+				}
+				nextPoints = append(nextPoints, SweepPoint{
+					Values: append(append([]string{}, point.Values...), value),
+				})
+				nextScenarios = append(nextScenarios, pointScenario)
+			}
+		}
+		points, scenarios = nextPoints, nextScenarios
+	}
+
+	// running it
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, parallel)
+	errs := make([]error, len(points))
+	for pointIdx := range points {
+		wg.Add(1)
+		go func(pointIdx int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			pointOpts := opts
+			pointOpts.TimeSeries = nil
+			result, err := scenarios[pointIdx].Run(pointOpts)
+			if err != nil {
+				errs[pointIdx] = fmt.Errorf("point %v: %w", points[pointIdx].Values, err)
+				return
+			}
+			points[pointIdx].Result = result
+			points[pointIdx].judge()
+		}(pointIdx)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return points, nil
+}
+
+func (point *SweepPoint) judge() {
+	result := point.Result
+	means := make([]float64, len(result.Strategies))
+	for strategyIdx := range means {
+		means[strategyIdx] = stats.Mean(result.GrowthRates(strategyIdx))
+	}
+
+	point.Winner, point.RunnerUp = 0, -1
+	for strategyIdx, mean := range means {
+		if mean > means[point.Winner] {
+			point.Winner = strategyIdx
+		}
+	}
+	for strategyIdx, mean := range means {
+		if strategyIdx == point.Winner {
+			continue
+		}
+		if point.RunnerUp < 0 || mean > means[point.RunnerUp] {
+			point.RunnerUp = strategyIdx
+		}
+	}
+
+	point.PValue = math.NaN()
+	if point.RunnerUp >= 0 {
+		rng := rand.New(rand.NewSource(result.Seed))
+		point.PValue = stats.PairedPermutationTest(
+			result.GrowthRates(point.Winner), result.GrowthRates(point.RunnerUp),
+			stats.DefaultResamples, rng,
+		)
+	}
+}
+
+// PrintSweep writes the table of the winners at each point of the grid and,
+// if there are exactly two axes, the phase diagram of the winners.
+func PrintSweep(w io.Writer, axes []SweepAxis, points []SweepPoint) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, axis := range axes {
+		fmt.Fprintf(tw, "%s\t", axis.Parameter)
+	}
+	fmt.Fprintf(tw, "winner\tgrowth rate\trunner-up\tgrowth rate\tp-value\t\n")
+	for _, point := range points {
+		for _, value := range point.Values {
+			fmt.Fprintf(tw, "%s\t", value)
+		}
+		result := point.Result
+		winner := result.Strategies[point.Winner]
+		winnerName := winner.Name
+		if point.IsTie() {
+			winnerName += " (tie)"
+		}
+		fmt.Fprintf(tw, "%s\t%.2f%%\t", winnerName, stats.Mean(result.GrowthRates(point.Winner)))
+		if point.RunnerUp >= 0 {
+			fmt.Fprintf(tw, "%s\t%.2f%%\t%.4f\t\n",
+				result.Strategies[point.RunnerUp].Name,
+				stats.Mean(result.GrowthRates(point.RunnerUp)),
+				point.PValue,
+			)
+		} else {
+			fmt.Fprintf(tw, "-\t-\t-\t\n")
+		}
+	}
+	tw.Flush()
+
+	if len(axes) != 2 {
+		return
+	}
+
+	fmt.Fprintf(w, "\nphase diagram (rows: %s, columns: %s; '~' marks ties):\n", axes[0].Parameter, axes[1].Parameter)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\t")
+	for _, value := range axes[1].Values {
+		fmt.Fprintf(tw, "%s\t", value)
+	}
+	fmt.Fprintf(tw, "\n")
+	for rowIdx, rowValue := range axes[0].Values {
+		fmt.Fprintf(tw, "%s\t", rowValue)
+		for columnIdx := range axes[1].Values {
+			point := points[rowIdx*len(axes[1].Values)+columnIdx]
+			name := point.Result.Strategies[point.Winner].Name
+			if point.IsTie() {
+				name = "~" + name
+			}
+			fmt.Fprintf(tw, "%s\t", name)
+		}
+		fmt.Fprintf(tw, "\n")
+	}
+	tw.Flush()
+}
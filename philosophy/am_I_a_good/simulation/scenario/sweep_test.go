@@ -0,0 +1,182 @@
+package scenario
+
+import (
+	"reflect"
+	"testing"
+)
+
+const sweepScenario = `
+name: sweep
+tries: 3
+weeks: 10
+world:
+  portion_energy: 2000
+population:
+  - strategy: eat_the_rest
+    citizens: 10
+  - strategy: reciprocity
+    citizens: 20
+`
+
+func TestWithParameter(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		parameter string
+		value     string
+		check     func(s *Scenario) bool
+	}{
+		{
+			name:      "top level",
+			parameter: "tries",
+			value:     "10",
+			check:     func(s *Scenario) bool { return s.Tries == 10 },
+		},
+		{
+			name:      "world",
+			parameter: "world.portion_energy",
+			value:     "3000",
+			check:     func(s *Scenario) bool { return s.World.PortionEnergy == 3000 },
+		},
+		{
+			name:      "list index",
+			parameter: "population.1.citizens",
+			value:     "5",
+			check: func(s *Scenario) bool {
+				return s.Population[0].Citizens == 10 && s.Population[1].Citizens == 5
+			},
+		},
+		{
+			name:      "list wildcard",
+			parameter: "population.*.citizens",
+			value:     "7",
+			check: func(s *Scenario) bool {
+				return s.Population[0].Citizens == 7 && s.Population[1].Citizens == 7
+			},
+		},
+		{
+			name:      "omitted section",
+			parameter: "population.1.parameters.kindness",
+			value:     "0.25",
+			check: func(s *Scenario) bool {
+				return s.Population[1].Parameters["kindness"] == 0.25 && s.Population[0].Parameters == nil
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			original, err := Parse([]byte(sweepScenario))
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, err := original.WithParameter(tc.parameter, tc.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tc.check(result) {
+				t.Errorf("%s=%s is not applied: %+v", tc.parameter, tc.value, result)
+			}
+			if original.Tries != 3 || original.World.PortionEnergy != 2000 ||
+				original.Population[0].Citizens != 10 || original.Population[1].Citizens != 20 ||
+				original.Population[1].Parameters != nil {
+				t.Errorf("the original scenario is modified: %+v", original)
+			}
+		})
+	}
+}
+
+func TestWithParameterErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		parameter string
+		value     string
+	}{
+		{name: "index out of range", parameter: "population.2.citizens", value: "5"},
+		{name: "negative index", parameter: "population.-1.citizens", value: "5"},
+		{name: "not an index", parameter: "population.first.citizens", value: "5"},
+		{name: "not a section", parameter: "name.suffix", value: "x"},
+		{name: "unknown field", parameter: "world.no_such_field", value: "1"},
+		{name: "invalid value", parameter: "tries", value: "["},
+		{name: "invalid scenario", parameter: "tries", value: "0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			original, err := Parse([]byte(sweepScenario))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := original.WithParameter(tc.parameter, tc.value); err == nil {
+				t.Errorf("expected an error for %s=%s", tc.parameter, tc.value)
+			}
+		})
+	}
+}
+
+func TestParseSweepAxis(t *testing.T) {
+	for _, tc := range []struct {
+		input  string
+		values []string
+		error  bool
+	}{
+		{input: "world.amount_of_portions=83,167,187", values: []string{"83", "167", "187"}},
+		{input: "world.amount_of_portions=100:200:50", values: []string{"100", "150", "200"}},
+		{input: "world.change_strategy_exponent=0.5:1:0.25", values: []string{"0.5", "0.75", "1"}},
+		{input: "world.amount_of_portions", error: true},
+		{input: "=1,2", error: true},
+		{input: "tries=1:2", error: true},
+		{input: "tries=2:1:1", error: true},
+		{input: "tries=1:2:0", error: true},
+		{input: "tries=1:x:1", error: true},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			axis, err := ParseSweepAxis(tc.input)
+			if tc.error {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", axis)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(axis.Values, tc.values) {
+				t.Errorf("values %v, expected %v", axis.Values, tc.values)
+			}
+		})
+	}
+}
+
+func TestSweep(t *testing.T) {
+	s, err := Parse([]byte(sweepScenario))
+	if err != nil {
+		t.Fatal(err)
+	}
+	axes := []SweepAxis{
+		{Parameter: "world.amount_of_portions", Values: []string{"5", "50"}},
+		{Parameter: "population.0.citizens", Values: []string{"1", "30"}},
+	}
+	points, err := s.Sweep(axes, RunOptions{Seed: 1}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedValues := [][]string{{"5", "1"}, {"5", "30"}, {"50", "1"}, {"50", "30"}}
+	if len(points) != len(expectedValues) {
+		t.Fatalf("%d points, expected %d", len(points), len(expectedValues))
+	}
+	for pointIdx, point := range points {
+		if !reflect.DeepEqual(point.Values, expectedValues[pointIdx]) {
+			t.Errorf("point #%d has values %v, expected %v", pointIdx, point.Values, expectedValues[pointIdx])
+		}
+		if point.Result == nil || len(point.Result.Tries) != int(s.Tries) {
+			t.Fatalf("point #%d has no result of all the tries: %+v", pointIdx, point.Result)
+		}
+		if point.Result.Scenario.Population[0].Citizens != map[string]uint{"1": 1, "30": 30}[point.Values[1]] {
+			t.Errorf("point #%d is run with %d citizens", pointIdx, point.Result.Scenario.Population[0].Citizens)
+		}
+		if point.Winner < 0 || point.Winner >= len(s.Population) || point.Winner == point.RunnerUp {
+			t.Errorf("point #%d has an invalid winner %d (the runner-up %d)", pointIdx, point.Winner, point.RunnerUp)
+		}
+	}
+
+	if _, err := s.Sweep([]SweepAxis{{Parameter: "tries", Values: []string{"0"}}}, RunOptions{}, 0); err == nil {
+		t.Error("expected an error for an invalid point")
+	}
+}
@@ -0,0 +1,159 @@
+// Package stats provides the statistics used to compare strategies
+// across tries.
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+const (
+	// DefaultResamples is the amount of bootstrap resamples
+	// (and of permutations of significance tests).
+	DefaultResamples = 10000
+
+	// DefaultConfidence is the confidence level of the intervals.
+	DefaultConfidence = 0.95
+
+	// DefaultAlpha is the significance level of the tests.
+	DefaultAlpha = 0.05
+)
+
+// Mean returns the arithmetic mean of the samples.
+func Mean(samples []float64) float64 {
+	if len(samples) == 0 {
+		return math.NaN()
+	}
+	sum := float64(0)
+	for _, sample := range samples {
+		sum += sample
+	}
+	return sum / float64(len(samples))
+}
+
+// StdDev returns the sample standard deviation.
+func StdDev(samples []float64) float64 {
+	if len(samples) < 2 {
+		return math.NaN()
+	}
+	mean := Mean(samples)
+	sum := float64(0)
+	for _, sample := range samples {
+		sum += (sample - mean) * (sample - mean)
+	}
+	return math.Sqrt(sum / float64(len(samples)-1))
+}
+
+// BootstrapCI returns the percentile bootstrap confidence interval
+// of the mean.
+func BootstrapCI(samples []float64, confidence float64, resamples int, rng *rand.Rand) (low, high float64) {
+	if len(samples) == 0 {
+		return math.NaN(), math.NaN()
+	}
+	means := make([]float64, resamples)
+	for resampleIdx := range means {
+		sum := float64(0)
+		for range samples {
+			sum += samples[rng.Intn(len(samples))]
+		}
+		means[resampleIdx] = sum / float64(len(samples))
+	}
+	sort.Float64s(means)
+	tail := (1 - confidence) / 2
+	return quantile(means, tail), quantile(means, 1-tail)
+}
+
+// quantile returns the q-th quantile of sorted values.
+func quantile(sorted []float64, q float64) float64 {
+	idx := int(q * float64(len(sorted)-1))
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// PairedPermutationTest returns the two-sided p-value of the hypothesis
+// that the paired samples a and b have the same mean. The samples are
+// paired by index (e.g. two strategies within the same try).
+//
+// It is a sign-flip permutation test, so it makes no assumptions about
+// the distribution of the samples.
+func PairedPermutationTest(a, b []float64, permutations int, rng *rand.Rand) float64 {
+	if len(a) != len(b) {
+		panic("the samples are not paired")
+	}
+	if len(a) == 0 {
+		return math.NaN()
+	}
+	diffs := make([]float64, len(a))
+	for idx := range a {
+		diffs[idx] = a[idx] - b[idx]
+	}
+	observed := math.Abs(Mean(diffs))
+
+	extreme := 0
+	for i := 0; i < permutations; i++ {
+		sum := float64(0)
+		for _, diff := range diffs {
+			if rng.Intn(2) == 0 {
+				sum += diff
+			} else {
+				sum -= diff
+			}
+		}
+		if math.Abs(sum/float64(len(diffs))) >= observed-1e-12 {
+			extreme++
+		}
+	}
+	return float64(extreme+1) / float64(permutations+1)
+}
+
+// HolmBonferroni returns the p-values adjusted for multiple comparisons
+// by the Holm-Bonferroni step-down method: a hypothesis is rejected at
+// the level alpha if its adjusted p-value is below alpha, which keeps
+// the probability of any false rejection below alpha. NaN p-values are
+// left as is and are not counted as comparisons.
+func HolmBonferroni(pValues []float64) []float64 {
+	order := make([]int, 0, len(pValues))
+	for idx, pValue := range pValues {
+		if !math.IsNaN(pValue) {
+			order = append(order, idx)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return pValues[order[i]] < pValues[order[j]]
+	})
+
+	adjusted := append([]float64{}, pValues...)
+	running := float64(0)
+	for rank, idx := range order {
+		running = math.Max(running, math.Min(1, float64(len(order)-rank)*pValues[idx]))
+		adjusted[idx] = running
+	}
+	return adjusted
+}
+
+// Summary describes a distribution of a metric across tries.
+type Summary struct {
+	N      int
+	Mean   float64
+	StdDev float64
+	CILow  float64
+	CIHigh float64
+}
+
+// Summarize calculates the Summary with the default parameters.
+func Summarize(samples []float64, rng *rand.Rand) Summary {
+	low, high := BootstrapCI(samples, DefaultConfidence, DefaultResamples, rng)
+	return Summary{
+		N:      len(samples),
+		Mean:   Mean(samples),
+		StdDev: StdDev(samples),
+		CILow:  low,
+		CIHigh: high,
+	}
+}
@@ -0,0 +1,172 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func equalFloats(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestMeanAndStdDev(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		samples []float64
+		mean    float64
+		stdDev  float64
+	}{
+		{name: "empty", samples: nil, mean: math.NaN(), stdDev: math.NaN()},
+		{name: "single", samples: []float64{5}, mean: 5, stdDev: math.NaN()},
+		{name: "constant", samples: []float64{3, 3, 3}, mean: 3, stdDev: 0},
+		{name: "sequence", samples: []float64{1, 2, 3, 4}, mean: 2.5, stdDev: math.Sqrt(5.0 / 3)},
+		{name: "textbook", samples: []float64{2, 4, 4, 4, 5, 5, 7, 9}, mean: 5, stdDev: math.Sqrt(32.0 / 7)},
+		{name: "negative", samples: []float64{-100, 0, 100}, mean: 0, stdDev: 100},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if mean := Mean(tc.samples); !equalFloats(mean, tc.mean) {
+				t.Errorf("Mean() = %v, expected %v", mean, tc.mean)
+			}
+			if stdDev := StdDev(tc.samples); !equalFloats(stdDev, tc.stdDev) {
+				t.Errorf("StdDev() = %v, expected %v", stdDev, tc.stdDev)
+			}
+		})
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5}
+	for _, tc := range []struct {
+		q        float64
+		expected float64
+	}{
+		{q: 0, expected: 1},
+		{q: 0.5, expected: 3},
+		{q: 0.9, expected: 4},
+		{q: 1, expected: 5},
+		{q: -0.5, expected: 1},
+		{q: 1.5, expected: 5},
+	} {
+		if result := quantile(sorted, tc.q); result != tc.expected {
+			t.Errorf("quantile(%v) = %v, expected %v", tc.q, result, tc.expected)
+		}
+	}
+}
+
+func TestBootstrapCI(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		samples  []float64
+		low      float64
+		high     float64
+		contains float64
+	}{
+		{name: "empty", samples: nil, low: math.NaN(), high: math.NaN()},
+		{name: "constant", samples: []float64{7, 7, 7, 7}, low: 7, high: 7, contains: 7},
+		{name: "spread", samples: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, low: 1, high: 10, contains: 5.5},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			low, high := BootstrapCI(tc.samples, DefaultConfidence, 1000, rand.New(rand.NewSource(0)))
+			if len(tc.samples) == 0 {
+				if !math.IsNaN(low) || !math.IsNaN(high) {
+					t.Errorf("BootstrapCI() = [%v, %v], expected NaNs", low, high)
+				}
+				return
+			}
+			if low < tc.low || high > tc.high || low > high {
+				t.Errorf("BootstrapCI() = [%v, %v] is not within [%v, %v]", low, high, tc.low, tc.high)
+			}
+			if tc.contains < low || tc.contains > high {
+				t.Errorf("BootstrapCI() = [%v, %v] does not contain the mean %v", low, high, tc.contains)
+			}
+		})
+	}
+}
+
+func TestPairedPermutationTest(t *testing.T) {
+	const permutations = 10000
+	for _, tc := range []struct {
+		name string
+		a    []float64
+		b    []float64
+		min  float64
+		max  float64
+	}{
+		{name: "empty", a: nil, b: nil, min: math.NaN(), max: math.NaN()},
+		{name: "identical", a: []float64{1, 5, 3}, b: []float64{1, 5, 3}, min: 1, max: 1},
+		{name: "single pair", a: []float64{10}, b: []float64{0}, min: 1, max: 1},
+		{
+			// only 2 of the 2^10 sign flips are as extreme
+			name: "always higher",
+			a:    []float64{11, 12, 13, 14, 15, 16, 17, 18, 19, 20},
+			b:    []float64{10, 11, 12, 13, 14, 15, 16, 17, 18, 19},
+			min:  0.0005,
+			max:  0.005,
+		},
+		{
+			name: "balanced",
+			a:    []float64{1, -1, 1, -1, 1, -1},
+			b:    []float64{-1, 1, -1, 1, -1, 1},
+			min:  1,
+			max:  1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pValue := PairedPermutationTest(tc.a, tc.b, permutations, rand.New(rand.NewSource(0)))
+			if math.IsNaN(tc.min) {
+				if !math.IsNaN(pValue) {
+					t.Errorf("PairedPermutationTest() = %v, expected NaN", pValue)
+				}
+				return
+			}
+			if pValue < tc.min || pValue > tc.max {
+				t.Errorf("PairedPermutationTest() = %v, expected within [%v, %v]", pValue, tc.min, tc.max)
+			}
+		})
+	}
+}
+
+func TestPairedPermutationTestUnpaired(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic on samples of different lengths")
+		}
+	}()
+	PairedPermutationTest([]float64{1, 2}, []float64{1}, 10, rand.New(rand.NewSource(0)))
+}
+
+func TestHolmBonferroni(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		pValues  []float64
+		expected []float64
+	}{
+		{name: "empty", pValues: nil, expected: []float64{}},
+		{name: "single", pValues: []float64{0.03}, expected: []float64{0.03}},
+		{
+			name:     "step-down",
+			pValues:  []float64{0.01, 0.04, 0.03, 0.005},
+			expected: []float64{0.03, 0.06, 0.06, 0.02},
+		},
+		{name: "capped", pValues: []float64{0.6, 0.7}, expected: []float64{1, 1}},
+		{name: "ties", pValues: []float64{0.01, 0.01, 0.01}, expected: []float64{0.03, 0.03, 0.03}},
+		{name: "NaN", pValues: []float64{math.NaN(), 0.02, 0.5}, expected: []float64{math.NaN(), 0.04, 0.5}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			adjusted := HolmBonferroni(tc.pValues)
+			if len(adjusted) != len(tc.expected) {
+				t.Fatalf("HolmBonferroni() = %v, expected %v", adjusted, tc.expected)
+			}
+			for idx := range adjusted {
+				if !equalFloats(adjusted[idx], tc.expected[idx]) {
+					t.Errorf("HolmBonferroni() = %v, expected %v", adjusted, tc.expected)
+					break
+				}
+			}
+		})
+	}
+}
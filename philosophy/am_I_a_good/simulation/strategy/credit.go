@@ -0,0 +1,74 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// Lender repays its own debts and lends to the hungry who defaulted
+// at most MaxDefaults times.
+type Lender struct {
+	MaxDefaults uint
+}
+
+func (strategy *Lender) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.childPreservation()
+	p.repayment()
+	p.lending(observation.HungryPeople(), func(candidate engine.PersonView) bool {
+		return candidate.Citizen().Defaults() <= strategy.MaxDefaults
+	})
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+// Borrower never gives anything for free, but repays its debts.
+type Borrower struct{}
+
+func (strategy *Borrower) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.childPreservation()
+	p.repayment()
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+func newLender(params Parameters) (engine.Strategy, error) {
+	maxDefaults := params["max_defaults"]
+	if maxDefaults < 0 || maxDefaults != float64(uint(maxDefaults)) {
+		return nil, fmt.Errorf("max_defaults should be a non-negative integer, but it is %f", maxDefaults)
+	}
+	return &Lender{MaxDefaults: uint(maxDefaults)}, nil
+}
+
+func requireLending(cfg *engine.Config) error {
+	if !cfg.EnableLending {
+		return fmt.Errorf("requires enable_lending")
+	}
+	return nil
+}
+
+func init() {
+	Register(Info{
+		Name:        "lender",
+		Description: "repays own debts and lends to the hungry who defaulted at most max_defaults times (lending)",
+		Parameters: []Parameter{
+			{Name: "max_defaults", Description: "how many defaults of a candidate to tolerate", Default: 0},
+		},
+		CheckWorld: requireLending,
+		Factory:    newLender,
+	})
+	Register(Info{
+		Name:        "borrower",
+		Description: "never helps anybody except own children, but repays own debts (lending)",
+		CheckWorld:  requireLending,
+		Factory:     newWithoutParameters(func() engine.Strategy { return &Borrower{} }),
+	})
+}
@@ -0,0 +1,93 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+func TestCreditStrategies(t *testing.T) {
+	cfg := engine.DefaultConfig()
+	cfg.EnableChildren = false
+	cfg.EnableAging = false
+	cfg.EnableLending = true
+	cfg.LendingTermInWeeks = 2
+	cfg.AmountOfPortions = 14
+	lender := &Lender{MaxDefaults: 1}
+	borrower := &Borrower{}
+
+	var debts, lent, refused uint
+	for seed := int64(1); seed <= 5; seed++ {
+		playground := engine.NewPlayground(cfg, seed)
+		playground.AddCitizens(lender, 15)
+		playground.AddCitizens(borrower, 15)
+		for week := 0; week < 20; week++ {
+			playground.IterateWeek()
+		}
+		checkCredit(t, playground, lender, &debts, &lent, &refused)
+	}
+	if debts == 0 || lent == 0 || refused == 0 {
+		t.Errorf("the history is not diverse enough for the test: %d debts, %d loans, %d refusals", debts, lent, refused)
+	}
+
+	for _, params := range []Parameters{{"max_defaults": -1}, {"max_defaults": 0.5}} {
+		if _, err := New("lender", params); err == nil {
+			t.Errorf("expected an error for %v", params)
+		}
+	}
+}
+
+// checkCredit makes everybody else starve and checks that every citizen
+// repays all its debts, and only the lenders lend, exactly to those
+// who did not default too many times.
+func checkCredit(t *testing.T, playground *engine.Playground, lender *Lender, debts, lent, refused *uint) {
+	cfg := &playground.Config
+	for _, citizen := range playground.Citizens {
+		owed := map[engine.PersonID]uint{}
+		total := uint(0)
+		for _, debt := range citizen.Debts() {
+			owed[debt.Creditor.ID()] += debt.Owed
+			total += debt.Owed
+		}
+		for _, other := range playground.Citizens {
+			other.HasEnergy, other.OwnsFood, other.HadEat = 0, 0, 0
+		}
+		citizen.HasEnergy = cfg.RequiredEnergy
+		food := engine.Food{Amount: total + cfg.RequiredEnergy*uint(len(playground.Citizens))}
+
+		repaid := map[engine.PersonID]uint{}
+		loans := map[engine.PersonID]bool{}
+		for _, action := range citizen.Strategy.HandleFood(playground.Observe(citizen), food) {
+			switch action.ActionType {
+			case engine.ActionTypeRepay:
+				repaid[action.Destination] += action.Amount
+			case engine.ActionTypeLend:
+				loans[action.Destination] = true
+			case engine.ActionTypeEat:
+				if action.Destination != citizen.ID() {
+					t.Errorf("a gift from %T", citizen.Strategy)
+				}
+			}
+		}
+
+		for creditor, amount := range owed {
+			*debts++
+			if repaid[creditor] != amount {
+				t.Errorf("%T repaid %d of %d", citizen.Strategy, repaid[creditor], amount)
+			}
+		}
+		for _, other := range playground.Citizens {
+			expected := citizen.Strategy == lender && other != citizen && other.Defaults <= lender.MaxDefaults
+			if loans[other.ID()] != expected {
+				t.Errorf("%T lends to a citizen with %d defaults: %v, expected %v",
+					citizen.Strategy, other.Defaults, loans[other.ID()], expected)
+			}
+			switch {
+			case expected:
+				*lent++
+			case citizen.Strategy == lender && other != citizen:
+				*refused++
+			}
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package strategy
+
+import (
+	"sort"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// ShareEverything splits every portion equally between all family members.
+type ShareEverything struct{}
+
+func (strategy *ShareEverything) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+
+	family := observation.Relatives()
+	oneShare := food.Amount / uint(len(family))
+	for _, relative := range family {
+		p.add(engine.ActionTypeEat, oneShare, relative.ID(), "sharing")
+	}
+
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+// EatTheRest eats everything it finds.
+type EatTheRest struct{}
+
+func (strategy *EatTheRest) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	return newPlan(observation, food).rest(engine.ActionTypeEat, "reserving")
+}
+
+// HideTheRest eats enough to survive and hides the rest
+// in small portions.
+type HideTheRest struct{}
+
+func (strategy *HideTheRest) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	for p.amount > 0 {
+		p.add(engine.ActionTypeHide, 100, observation.Self.ID(), "hiding")
+	}
+	return p.actions
+}
+
+// ShareAndHideTheRest saves hungry family members and hides the rest.
+type ShareAndHideTheRest struct{}
+
+func (strategy *ShareAndHideTheRest) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.altruism(observation.Relatives(), nil)
+	return p.rest(engine.ActionTypeHide, "hiding")
+}
+
+// ShareTheRest saves hungry family members and uses the rest
+// to equalize the food eaten by the family members.
+type ShareTheRest struct{}
+
+func (strategy *ShareTheRest) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	requiredEnergy := p.cfg.RequiredEnergy
+	p.selfPreservation()
+	family := observation.Relatives()
+	p.altruism(family, nil)
+	if p.amount == 0 {
+		return p.actions
+	}
+
+	// use the rest food to equalize the eat energy
+
+	hadEat := func(candidate engine.PersonView) uint {
+		result := candidate.HadEat()
+		for _, action := range p.actions {
+			if action.Destination == candidate.ID() {
+				result += action.Amount
+			}
+		}
+		return result
+	}
+
+	// calculating the minimal amount of food everybody should get, part 1
+	var energies []uint
+	for _, candidate := range family {
+		if candidate.TotalEnergy() < requiredEnergy {
+			// they will be dead anyway
+			continue
+		}
+		energies = append(energies, hadEat(candidate))
+	}
+	sort.Slice(energies, func(i, j int) bool {
+		return energies[i] < energies[j]
+	})
+
+	if len(energies) > 0 {
+		// calculating the minimal amount of food everybody should get, part 2
+		amount := p.amount
+		feedeesCount := uint(0)
+		lowEatBar := uint(0)
+		energies = append(energies, energies[len(energies)-1]+food.Amount) // adding a fake element to the end
+		prevEnergy := energies[0]
+		for _, energy := range energies[1:] {
+			feedeesCount++
+
+			if energy == prevEnergy {
+				continue
+			}
+
+			energyDiff := energy - prevEnergy
+			if energyDiff*feedeesCount > amount {
+				toEat := amount / feedeesCount
+				lowEatBar = prevEnergy + toEat
+				amount -= toEat * feedeesCount
+				break
+			}
+			lowEatBar = energy
+			amount -= feedeesCount * energyDiff
+			prevEnergy = energy
+		}
+
+		// sharing the food
+		var shares []engine.Action
+		for _, candidate := range family {
+			if candidate.TotalEnergy() < requiredEnergy {
+				// they will be dead anyway
+				continue
+			}
+
+			candidateHadEat := hadEat(candidate)
+			if candidateHadEat >= lowEatBar {
+				continue
+			}
+
+			shares = append(shares, engine.Action{
+				ActionType:  engine.ActionTypeEat,
+				Amount:      lowEatBar - candidateHadEat,
+				Destination: candidate.ID(),
+				Comment:     "equalizing",
+			})
+		}
+		for _, share := range shares {
+			p.add(share.ActionType, share.Amount, share.Destination, share.Comment)
+		}
+	}
+
+	// if something left, the eat the rest
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+func init() {
+	Register(Info{
+		Name:        "share_everything",
+		Description: "splits every portion equally between all family members, including the starving self",
+		Suicidal:    true,
+		Factory:     newWithoutParameters(func() engine.Strategy { return &ShareEverything{} }),
+	})
+	Register(Info{
+		Name:        "eat_the_rest",
+		Description: "eats everything it finds",
+		Factory:     newWithoutParameters(func() engine.Strategy { return &EatTheRest{} }),
+	})
+	Register(Info{
+		Name:        "hide_the_rest",
+		Description: "eats enough to survive and hides the rest in small portions",
+		Factory:     newWithoutParameters(func() engine.Strategy { return &HideTheRest{} }),
+	})
+	Register(Info{
+		Name:        "share_and_hide_the_rest",
+		Description: "saves hungry family members and hides the rest",
+		Factory:     newWithoutParameters(func() engine.Strategy { return &ShareAndHideTheRest{} }),
+	})
+	Register(Info{
+		Name:        "share_the_rest",
+		Description: "saves hungry family members and uses the rest to equalize the food eaten by the family",
+		Factory:     newWithoutParameters(func() engine.Strategy { return &ShareTheRest{} }),
+	})
+}
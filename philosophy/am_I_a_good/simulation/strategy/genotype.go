@@ -0,0 +1,202 @@
+package strategy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// Gene is a discrete trait of a CulturalGenotype.
+type Gene uint
+
+const (
+	// GeneSelfPreservation is how many weeks of RequiredEnergy
+	// the citizen secures for itself first (the allele plus one).
+	GeneSelfPreservation = Gene(iota)
+
+	// GeneChildFeeding defines when the own children are fed
+	// (see childFeedingAlleles).
+	GeneChildFeeding
+
+	// GeneAltruismFilter defines whom the citizen helps
+	// (see altruismFilterAlleles).
+	GeneAltruismFilter
+
+	// GeneRemainder defines what is done with the rest of the food
+	// (see remainderAlleles).
+	GeneRemainder
+
+	endOfGene
+)
+
+var geneNames = [endOfGene]string{
+	GeneSelfPreservation: "self_preservation",
+	GeneChildFeeding:     "child_feeding",
+	GeneAltruismFilter:   "altruism",
+	GeneRemainder:        "remainder",
+}
+
+const selfPreservationAlleles = 3
+
+var childFeedingAlleles = []string{"before_altruism", "after_altruism", "never"}
+
+var altruismFilterAlleles = []string{
+	"nobody",
+	"family",
+	"never_greedy",
+	"not_greedy_last_time",
+	"mirror",
+	"kind_mirror",
+	"everybody",
+}
+
+var remainderAlleles = []string{"eat", "hide"}
+
+var geneAlleles = [endOfGene]uint{
+	GeneSelfPreservation: selfPreservationAlleles,
+	GeneChildFeeding:     uint(len(childFeedingAlleles)),
+	GeneAltruismFilter:   uint(len(altruismFilterAlleles)),
+	GeneRemainder:        uint(len(remainderAlleles)),
+}
+
+// CulturalGenotype is a strategy composed of discrete cultural genes
+// (see engine.Genotype).
+type CulturalGenotype struct {
+	genes [endOfGene]uint
+}
+
+var _ engine.Genotype = (*CulturalGenotype)(nil)
+
+func (strategy *CulturalGenotype) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+
+	toSecure := int64(p.cfg.RequiredEnergy)*int64(strategy.genes[GeneSelfPreservation]+1) - int64(observation.Self.HasEnergy())
+	if toSecure > 0 {
+		p.add(engine.ActionTypeEat, uint(toSecure), observation.Self.ID(), "self-preservation")
+	}
+
+	childFeeding := childFeedingAlleles[strategy.genes[GeneChildFeeding]]
+	if childFeeding == "before_altruism" {
+		p.childPreservation()
+	}
+	if filter, people := strategy.altruism(observation); people != nil {
+		p.altruism(people, filter)
+	}
+	if childFeeding == "after_altruism" {
+		p.childPreservation()
+	}
+
+	switch remainderAlleles[strategy.genes[GeneRemainder]] {
+	case "hide":
+		return p.rest(engine.ActionTypeHide, "hiding")
+	default:
+		return p.rest(engine.ActionTypeEat, "reserving")
+	}
+}
+
+func (strategy *CulturalGenotype) altruism(observation *engine.Observation) (func(engine.PersonView) bool, []engine.PersonView) {
+	switch altruismFilterAlleles[strategy.genes[GeneAltruismFilter]] {
+	case "nobody":
+		return nil, nil
+	case "family":
+		return nil, observation.Relatives()
+	case "never_greedy":
+		return func(candidate engine.PersonView) bool {
+			return !candidate.Citizen().SpottedAsGreedyOnce()
+		}, observation.HungryPeople()
+	case "not_greedy_last_time":
+		return func(candidate engine.PersonView) bool {
+			return !candidate.Citizen().SpottedAsGreedyLastTime()
+		}, observation.HungryPeople()
+	case "mirror":
+		return (&Reciprocity{Kindness: 1}).deserves, observation.HungryPeople()
+	case "kind_mirror":
+		return (&Reciprocity{Kindness: 2}).deserves, observation.HungryPeople()
+	default:
+		return nil, observation.HungryPeople()
+	}
+}
+
+func (strategy *CulturalGenotype) Genes() []uint {
+	return strategy.genes[:]
+}
+
+func (strategy *CulturalGenotype) Alleles() []uint {
+	return geneAlleles[:]
+}
+
+func (strategy *CulturalGenotype) WithGenes(genes []uint) engine.Genotype {
+	result := &CulturalGenotype{}
+	copy(result.genes[:], genes)
+	return result
+}
+
+// String returns the genes, e.g. "self_preservation=0,child_feeding=before_altruism,...".
+func (strategy *CulturalGenotype) String() string {
+	alleles := [endOfGene]string{
+		GeneSelfPreservation: fmt.Sprint(strategy.genes[GeneSelfPreservation]),
+		GeneChildFeeding:     childFeedingAlleles[strategy.genes[GeneChildFeeding]],
+		GeneAltruismFilter:   altruismFilterAlleles[strategy.genes[GeneAltruismFilter]],
+		GeneRemainder:        remainderAlleles[strategy.genes[GeneRemainder]],
+	}
+	parts := make([]string, 0, endOfGene)
+	for gene, allele := range alleles {
+		parts = append(parts, geneNames[gene]+"="+allele)
+	}
+	return strings.Join(parts, ",")
+}
+
+func newCulturalGenotype(params Parameters) (engine.Strategy, error) {
+	strategy := &CulturalGenotype{}
+	for gene := Gene(0); gene < endOfGene; gene++ {
+		allele := params[geneNames[gene]]
+		if allele < 0 || allele != float64(uint(allele)) || uint(allele) >= geneAlleles[gene] {
+			return nil, fmt.Errorf("%s should be an integer within [0, %d], but it is %f",
+				geneNames[gene], geneAlleles[gene]-1, allele)
+		}
+		strategy.genes[gene] = uint(allele)
+	}
+	return strategy, nil
+}
+
+func alleleList(alleles []string) string {
+	parts := make([]string, 0, len(alleles))
+	for idx, allele := range alleles {
+		parts = append(parts, fmt.Sprintf("%d=%s", idx, allele))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func init() {
+	Register(Info{
+		Name:        "genotype",
+		Description: "a strategy composed of cultural genes which can be borrowed individually and mutate",
+		Parameters: []Parameter{
+			{
+				Name:        geneNames[GeneSelfPreservation],
+				Description: "how many weeks of required energy to secure first, minus one (0..2)",
+				Default:     0,
+			},
+			{
+				Name:        geneNames[GeneChildFeeding],
+				Description: "when to feed own children: " + alleleList(childFeedingAlleles),
+				Default:     0,
+			},
+			{
+				Name:        geneNames[GeneAltruismFilter],
+				Description: "whom to help: " + alleleList(altruismFilterAlleles),
+				Default:     5,
+			},
+			{
+				Name:        geneNames[GeneRemainder],
+				Description: "what to do with the rest: " + alleleList(remainderAlleles),
+				Default:     0,
+			},
+		},
+		Factory: newCulturalGenotype,
+	})
+}
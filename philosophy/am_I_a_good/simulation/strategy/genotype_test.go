@@ -0,0 +1,46 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+func TestCulturalGenotype(t *testing.T) {
+	strategy, err := New("genotype", Parameters{"self_preservation": 2, "altruism": 0, "remainder": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	genotype := strategy.(*CulturalGenotype)
+	expected := "self_preservation=2,child_feeding=before_altruism,altruism=nobody,remainder=hide"
+	if genotype.String() != expected {
+		t.Errorf("unexpected genes: %s", genotype)
+	}
+	if other := genotype.WithGenes(genotype.Genes()); other == engine.Genotype(genotype) || engine.GenotypeKey(other) != engine.GenotypeKey(genotype) {
+		t.Errorf("WithGenes does not make a copy with the same genes")
+	}
+
+	// secures three weeks, helps nobody and hides the rest
+	cfg := engine.DefaultConfig()
+	playground := engine.NewPlayground(cfg, 1)
+	playground.AddCitizens(genotype, 1)
+	playground.AddCitizens(&EatTheRest{}, 1)
+	citizen, hungry := playground.Citizens[0], playground.Citizens[1]
+	citizen.HasEnergy, hungry.HasEnergy = cfg.RequiredEnergy, 0
+	amounts := map[engine.ActionType]uint{}
+	for _, action := range genotype.HandleFood(playground.Observe(citizen), engine.Food{Amount: cfg.RequiredEnergy * 3}) {
+		if action.Destination != citizen.ID() {
+			t.Errorf("helped somebody: %+v", action)
+		}
+		amounts[action.ActionType] += action.Amount
+	}
+	if amounts[engine.ActionTypeEat] != cfg.RequiredEnergy*2 || amounts[engine.ActionTypeHide] != cfg.RequiredEnergy {
+		t.Errorf("unexpected actions: %v", amounts)
+	}
+
+	for _, params := range []Parameters{{"altruism": 7}, {"remainder": -1}, {"self_preservation": 0.5}} {
+		if _, err := New("genotype", params); err == nil {
+			t.Errorf("expected an error for %v", params)
+		}
+	}
+}
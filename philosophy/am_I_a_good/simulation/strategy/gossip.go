@@ -0,0 +1,98 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// GossipReciprocity helps the hungry whose reputation heard through
+// gossip is good enough:
+//
+//	HelpReports*Kindness + Forgiveness >= GreedyReports
+type GossipReciprocity struct {
+	Kindness    float64
+	Forgiveness float64
+}
+
+func (strategy *GossipReciprocity) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.childPreservation()
+	p.altruism(observation.HungryPeople(), func(candidate engine.PersonView) bool {
+		reputation := observation.Reputation(candidate.Citizen())
+		return float64(reputation.HelpReports)*strategy.Kindness+strategy.Forgiveness >= float64(reputation.GreedyReports)
+	})
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+// Liar never helps anybody except own children, and when retelling
+// rumors praises itself and smears everybody else.
+type Liar struct {
+	DoNotTrust
+
+	// LieProbability is the probability to distort a retold rumor.
+	LieProbability float64
+}
+
+var _ engine.GossipReporter = (*Liar)(nil)
+
+func (strategy *Liar) ReportRumor(observation *engine.Observation, subject engine.CitizenView, greedy bool) bool {
+	if observation.RandFloat64() >= strategy.LieProbability {
+		return greedy
+	}
+	return subject.Strategy() != observation.Self.Citizen().Strategy()
+}
+
+func newGossipReciprocity(params Parameters) (engine.Strategy, error) {
+	strategy := &GossipReciprocity{
+		Kindness:    params["kindness"],
+		Forgiveness: params["forgiveness"],
+	}
+	if strategy.Kindness < 0 {
+		return nil, fmt.Errorf("kindness should not be negative, but it is %f", strategy.Kindness)
+	}
+	return strategy, nil
+}
+
+func newLiar(params Parameters) (engine.Strategy, error) {
+	strategy := &Liar{
+		LieProbability: params["lie_probability"],
+	}
+	if strategy.LieProbability < 0 || strategy.LieProbability > 1 {
+		return nil, fmt.Errorf("lie_probability should be within [0, 1], but it is %f", strategy.LieProbability)
+	}
+	return strategy, nil
+}
+
+func requireGossip(cfg *engine.Config) error {
+	if !cfg.EnableGossip {
+		return fmt.Errorf("requires enable_gossip")
+	}
+	return nil
+}
+
+func init() {
+	Register(Info{
+		Name:        "gossip_reciprocity",
+		Description: "helps the hungry with at most kindness*help_reports+forgiveness greedy reports heard (gossip)",
+		Parameters: []Parameter{
+			{Name: "kindness", Description: "how many greedy reports one help report outweighs", Default: 1},
+			{Name: "forgiveness", Description: "how many greedy reports to tolerate", Default: 0},
+		},
+		CheckWorld: requireGossip,
+		Factory:    newGossipReciprocity,
+	})
+	Register(Info{
+		Name:        "liar",
+		Description: "never helps anybody except own children, praises own kind and smears others when gossiping (gossip)",
+		Parameters: []Parameter{
+			{Name: "lie_probability", Description: "the probability to distort a retold rumor", Default: 1},
+		},
+		CheckWorld: requireGossip,
+		Factory:    newLiar,
+	})
+}
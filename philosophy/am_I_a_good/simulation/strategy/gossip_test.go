@@ -0,0 +1,59 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+func TestGossipReciprocity(t *testing.T) {
+	strategy, err := New("gossip_reciprocity", Parameters{"kindness": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDeserves(t,
+		func(cfg *engine.Config) { cfg.EnableGossip = true },
+		strategy,
+		func(citizen, candidate *engine.Citizen) bool {
+			reputation := citizen.Reputation(candidate)
+			return reputation.HelpReports*2 >= reputation.GreedyReports
+		},
+	)
+}
+
+func TestLiar(t *testing.T) {
+	cfg := engine.DefaultConfig()
+	cfg.EnableGossip = true
+	playground := engine.NewPlayground(cfg, 1)
+	liar := &Liar{LieProbability: 1}
+	playground.AddCitizens(liar, 2)
+	playground.AddCitizens(&EatTheRest{}, 1)
+	citizen, fellow, stranger := playground.Citizens[0], playground.Citizens[1], playground.Citizens[2]
+
+	for _, tc := range []struct {
+		name           string
+		lieProbability float64
+		subject        *engine.Citizen
+		greedy         bool
+		reported       bool
+	}{
+		{name: "praises own kind", lieProbability: 1, subject: fellow, greedy: true, reported: false},
+		{name: "smears others", lieProbability: 1, subject: stranger, greedy: false, reported: true},
+		{name: "honest", lieProbability: 0, subject: stranger, greedy: false, reported: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			liar.LieProbability = tc.lieProbability
+			observation := playground.Observe(citizen)
+			subject := viewOf(t, observation, &tc.subject.Person).Citizen()
+			if reported := liar.ReportRumor(observation, subject, tc.greedy); reported != tc.reported {
+				t.Errorf("reported greedy: %v, expected %v", reported, tc.reported)
+			}
+		})
+	}
+
+	for _, params := range []Parameters{{"lie_probability": -0.1}, {"lie_probability": 1.1}} {
+		if _, err := New("liar", params); err == nil {
+			t.Errorf("expected an error for %v", params)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package strategy
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// Dynast never helps anybody except own children, and bequeaths
+// everything to the children, or to the poorest relative if there
+// are no children.
+type Dynast struct {
+	DoNotTrust
+}
+
+var _ engine.Testator = (*Dynast)(nil)
+
+func (strategy *Dynast) Will(observation *engine.Observation) []engine.Bequest {
+	if len(observation.Children) > 0 {
+		var will []engine.Bequest
+		for _, child := range observation.Children {
+			will = append(will, engine.Bequest{
+				Heir:  child.ID(),
+				Share: 1 / float64(len(observation.Children)),
+			})
+		}
+		return will
+	}
+	relatives := poorestFirst(observation.Relatives())
+	if len(relatives) == 0 {
+		return nil
+	}
+	return []engine.Bequest{{Heir: relatives[0].ID(), Share: 1}}
+}
+
+// Philanthropist helps all the hungry and bequeaths everything equally
+// to the Heirs poorest citizens outside of the family.
+type Philanthropist struct {
+	TrustAlways
+
+	Heirs uint
+}
+
+var _ engine.Testator = (*Philanthropist)(nil)
+
+func (strategy *Philanthropist) Will(observation *engine.Observation) []engine.Bequest {
+	relatives := map[engine.PersonID]bool{}
+	for _, relative := range observation.Relatives() {
+		relatives[relative.ID()] = true
+	}
+	var strangers []engine.PersonView
+	for _, candidate := range observation.Citizens() {
+		if !relatives[candidate.ID()] {
+			strangers = append(strangers, candidate)
+		}
+	}
+	strangers = poorestFirst(strangers)
+	if uint(len(strangers)) > strategy.Heirs {
+		strangers = strangers[:strategy.Heirs]
+	}
+	var will []engine.Bequest
+	for _, heir := range strangers {
+		will = append(will, engine.Bequest{
+			Heir:  heir.ID(),
+			Share: 1 / float64(len(strangers)),
+		})
+	}
+	return will
+}
+
+func poorestFirst(people []engine.PersonView) []engine.PersonView {
+	sort.SliceStable(people, func(i, j int) bool {
+		return people[i].TotalEnergy() < people[j].TotalEnergy()
+	})
+	return people
+}
+
+func newPhilanthropist(params Parameters) (engine.Strategy, error) {
+	heirs := params["heirs"]
+	if heirs <= 0 || heirs != float64(uint(heirs)) {
+		return nil, fmt.Errorf("heirs should be a positive integer, but it is %f", heirs)
+	}
+	return &Philanthropist{Heirs: uint(heirs)}, nil
+}
+
+func requireBequests(cfg *engine.Config) error {
+	if !cfg.EnableBequests {
+		return fmt.Errorf("requires enable_bequests")
+	}
+	return nil
+}
+
+func init() {
+	Register(Info{
+		Name:        "dynast",
+		Description: "never helps anybody except own children and bequeaths everything to them (bequests)",
+		CheckWorld:  requireBequests,
+		Factory:     newWithoutParameters(func() engine.Strategy { return &Dynast{} }),
+	})
+	Register(Info{
+		Name:        "philanthropist",
+		Description: "helps all the hungry and bequeaths everything to the poorest strangers (bequests)",
+		Parameters: []Parameter{
+			{Name: "heirs", Description: "the amount of the poorest strangers to bequeath to", Default: 10},
+		},
+		CheckWorld: requireBequests,
+		Factory:    newPhilanthropist,
+	})
+}
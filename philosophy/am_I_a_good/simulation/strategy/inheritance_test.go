@@ -0,0 +1,56 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+func TestDynast(t *testing.T) {
+	cfg := engine.DefaultConfig()
+	playground := engine.NewPlayground(cfg, 1)
+	strategy := &Dynast{}
+	playground.AddCitizens(strategy, 3)
+	citizen, rich, poor := playground.Citizens[0], playground.Citizens[1], playground.Citizens[2]
+	rich.HasEnergy, poor.HasEnergy = 2000, 1000
+
+	// the poorest relative without children
+	playground.RemoveCitizen(citizen)
+	if will := strategy.Will(playground.Observe(citizen)); len(will) != 1 || will[0].Heir != poor.ID() || will[0].Share != 1 {
+		t.Errorf("unexpected will: %+v", will)
+	}
+
+	// the children equally otherwise
+	citizen.HasEnergy = cfg.CreateBabyEnergy * 2
+	citizen.CreateBaby()
+	citizen.CreateBaby()
+	will := strategy.Will(playground.Observe(citizen))
+	if len(will) != 2 || will[0].Share != 0.5 || will[1].Share != 0.5 ||
+		will[0].Heir != citizen.Children[0].ID() || will[1].Heir != citizen.Children[1].ID() {
+		t.Errorf("unexpected will: %+v", will)
+	}
+}
+
+func TestPhilanthropist(t *testing.T) {
+	cfg := engine.DefaultConfig()
+	playground := engine.NewPlayground(cfg, 1)
+	strategy := &Philanthropist{Heirs: 2}
+	playground.AddCitizens(strategy, 2)
+	playground.AddCitizens(&EatTheRest{}, 3)
+	citizen := playground.Citizens[0]
+	for idx, other := range playground.Citizens {
+		other.HasEnergy = uint(10-idx) * 1000
+	}
+
+	will := strategy.Will(playground.Observe(citizen))
+	if len(will) != 2 || will[0].Heir != playground.Citizens[4].ID() || will[1].Heir != playground.Citizens[3].ID() ||
+		will[0].Share != 0.5 || will[1].Share != 0.5 {
+		t.Errorf("unexpected will: %+v", will)
+	}
+
+	for _, params := range []Parameters{{"heirs": 0}, {"heirs": 1.5}} {
+		if _, err := New("philanthropist", params); err == nil {
+			t.Errorf("expected an error for %v", params)
+		}
+	}
+}
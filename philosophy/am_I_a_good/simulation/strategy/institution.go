@@ -0,0 +1,50 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// TaxEvader never helps anybody except own children and hides found
+// food portions from the tax collector with EvadeProbability.
+type TaxEvader struct {
+	DoNotTrust
+
+	EvadeProbability float64
+}
+
+var _ engine.TaxEvader = (*TaxEvader)(nil)
+
+func (strategy *TaxEvader) EvadeTax(observation *engine.Observation, food engine.Food) bool {
+	return observation.RandFloat64() < strategy.EvadeProbability
+}
+
+func newTaxEvader(params Parameters) (engine.Strategy, error) {
+	strategy := &TaxEvader{
+		EvadeProbability: params["evade_probability"],
+	}
+	if strategy.EvadeProbability < 0 || strategy.EvadeProbability > 1 {
+		return nil, fmt.Errorf("evade_probability should be within [0, 1], but it is %f", strategy.EvadeProbability)
+	}
+	return strategy, nil
+}
+
+func requireInstitution(cfg *engine.Config) error {
+	if !cfg.EnableInstitution {
+		return fmt.Errorf("requires enable_institution")
+	}
+	return nil
+}
+
+func init() {
+	Register(Info{
+		Name:        "tax_evader",
+		Description: "never helps anybody except own children and evades taxes (institution)",
+		Parameters: []Parameter{
+			{Name: "evade_probability", Description: "the probability to evade the tax on a found portion", Default: 1},
+		},
+		CheckWorld: requireInstitution,
+		Factory:    newTaxEvader,
+	})
+}
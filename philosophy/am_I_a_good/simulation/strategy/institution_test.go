@@ -0,0 +1,30 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+func TestTaxEvader(t *testing.T) {
+	cfg := engine.DefaultConfig()
+	cfg.EnableInstitution = true
+	playground := engine.NewPlayground(cfg, 1)
+	playground.AddCitizens(&EatTheRest{}, 1)
+	citizen := playground.Citizens[0]
+
+	for _, evadeProbability := range []float64{0, 1} {
+		strategy := &TaxEvader{EvadeProbability: evadeProbability}
+		for try := 0; try < 10; try++ {
+			if evades := strategy.EvadeTax(playground.Observe(citizen), engine.Food{Amount: 1000}); evades != (evadeProbability == 1) {
+				t.Errorf("evades: %v with the probability %f", evades, evadeProbability)
+			}
+		}
+	}
+
+	for _, params := range []Parameters{{"evade_probability": -0.1}, {"evade_probability": 1.1}} {
+		if _, err := New("tax_evader", params); err == nil {
+			t.Errorf("expected an error for %v", params)
+		}
+	}
+}
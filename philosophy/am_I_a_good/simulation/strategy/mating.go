@@ -0,0 +1,57 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// AssortativeMater behaves as trust_kind_mirror, but makes babies only
+// with partners following the same strategy.
+type AssortativeMater struct {
+	Reciprocity
+}
+
+var _ engine.MateChooser = (*AssortativeMater)(nil)
+
+func (strategy *AssortativeMater) AcceptsMate(observation *engine.Observation, candidate engine.CitizenView) bool {
+	return candidate.Strategy() == observation.Self.Citizen().Strategy()
+}
+
+// ReputationMater behaves as trust_kind_mirror, but makes babies only
+// with partners who were never spotted as greedy.
+type ReputationMater struct {
+	Reciprocity
+}
+
+var _ engine.MateChooser = (*ReputationMater)(nil)
+
+func (strategy *ReputationMater) AcceptsMate(observation *engine.Observation, candidate engine.CitizenView) bool {
+	return !candidate.SpottedAsGreedyOnce()
+}
+
+func requireTwoParentReproduction(cfg *engine.Config) error {
+	if !cfg.EnableTwoParentReproduction {
+		return fmt.Errorf("requires enable_two_parent_reproduction")
+	}
+	return nil
+}
+
+func init() {
+	Register(Info{
+		Name:        "assortative_mater",
+		Description: "trust_kind_mirror which makes babies only with partners of the same strategy (two-parent reproduction)",
+		CheckWorld:  requireTwoParentReproduction,
+		Factory: newWithoutParameters(func() engine.Strategy {
+			return &AssortativeMater{Reciprocity: Reciprocity{Kindness: 2}}
+		}),
+	})
+	Register(Info{
+		Name:        "reputation_mater",
+		Description: "trust_kind_mirror which makes babies only with partners never spotted as greedy (two-parent reproduction)",
+		CheckWorld:  requireTwoParentReproduction,
+		Factory: newWithoutParameters(func() engine.Strategy {
+			return &ReputationMater{Reciprocity: Reciprocity{Kindness: 2}}
+		}),
+	})
+}
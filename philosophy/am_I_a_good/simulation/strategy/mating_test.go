@@ -0,0 +1,29 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+func TestMateChoosers(t *testing.T) {
+	cfg := engine.DefaultConfig()
+	cfg.EnableTwoParentReproduction = true
+	playground := engine.NewPlayground(cfg, 1)
+	assortative, reputation := &AssortativeMater{}, &ReputationMater{}
+	playground.AddCitizens(assortative, 2)
+	playground.AddCitizens(reputation, 2)
+	sameA, otherA, sameR, greedyR := playground.Citizens[0], playground.Citizens[1], playground.Citizens[2], playground.Citizens[3]
+	greedyR.SpottedAsGreedyOnce = true
+
+	acceptsMate := func(chooser engine.MateChooser, citizen, candidate *engine.Citizen) bool {
+		observation := playground.Observe(citizen)
+		return chooser.AcceptsMate(observation, viewOf(t, observation, &candidate.Person).Citizen())
+	}
+	if !acceptsMate(assortative, sameA, otherA) || acceptsMate(assortative, sameA, sameR) {
+		t.Errorf("AssortativeMater accepts partners of other strategies or rejects of its own")
+	}
+	if !acceptsMate(reputation, sameR, sameA) || acceptsMate(reputation, sameR, greedyR) {
+		t.Errorf("ReputationMater accepts the greedy or rejects the others")
+	}
+}
@@ -0,0 +1,62 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// Adopter helps all the hungry and adopts orphans while it has at
+// least MinEnergy energy.
+type Adopter struct {
+	TrustAlways
+
+	MinEnergy uint
+}
+
+var _ engine.Adopter = (*Adopter)(nil)
+
+func (strategy *Adopter) WantsToAdopt(observation *engine.Observation, orphan engine.PersonView) bool {
+	return observation.Self.HasEnergy() >= strategy.MinEnergy
+}
+
+// ChildSupporter never helps adults except itself, but saves all
+// the hungry children, own ones first.
+type ChildSupporter struct{}
+
+func (strategy *ChildSupporter) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.childPreservation()
+	p.altruism(observation.HungryPeople(), func(candidate engine.PersonView) bool {
+		return candidate.IsChild()
+	})
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+func newAdopter(params Parameters) (engine.Strategy, error) {
+	minEnergy := params["min_energy"]
+	if minEnergy < 0 || minEnergy != float64(uint(minEnergy)) {
+		return nil, fmt.Errorf("min_energy should be a non-negative integer, but it is %f", minEnergy)
+	}
+	return &Adopter{MinEnergy: uint(minEnergy)}, nil
+}
+
+func init() {
+	Register(Info{
+		Name:        "adopter",
+		Description: "helps all the hungry and adopts orphans while having at least min_energy",
+		Parameters: []Parameter{
+			{Name: "min_energy", Description: "the energy required to adopt an orphan", Default: 40000},
+		},
+		Factory: newAdopter,
+	})
+	Register(Info{
+		Name:        "child_supporter",
+		Description: "saves all the hungry children, but no other adults",
+		Factory:     newWithoutParameters(func() engine.Strategy { return &ChildSupporter{} }),
+	})
+}
@@ -0,0 +1,59 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+func TestAdopter(t *testing.T) {
+	cfg := engine.DefaultConfig()
+	playground := engine.NewPlayground(cfg, 1)
+	strategy := &Adopter{MinEnergy: 1000}
+	playground.AddCitizens(strategy, 1)
+	playground.AddCitizens(&EatTheRest{}, 1)
+	citizen, parent := playground.Citizens[0], playground.Citizens[1]
+	parent.HasEnergy = cfg.CreateBabyEnergy
+	parent.CreateBaby()
+	wantsToAdopt := func() bool {
+		observation := playground.Observe(citizen)
+		return strategy.WantsToAdopt(observation, viewOf(t, observation, &parent.Children[0].Person))
+	}
+
+	citizen.HasEnergy = 1000
+	if !wantsToAdopt() {
+		t.Errorf("does not adopt having enough energy")
+	}
+	citizen.HasEnergy = 999
+	if wantsToAdopt() {
+		t.Errorf("adopts having not enough energy")
+	}
+
+	for _, params := range []Parameters{{"min_energy": -1}, {"min_energy": 0.5}} {
+		if _, err := New("adopter", params); err == nil {
+			t.Errorf("expected an error for %v", params)
+		}
+	}
+}
+
+func TestChildSupporter(t *testing.T) {
+	cfg := engine.DefaultConfig()
+	playground := engine.NewPlayground(cfg, 1)
+	strategy := &ChildSupporter{}
+	playground.AddCitizens(strategy, 1)
+	playground.AddCitizens(&EatTheRest{}, 2)
+	citizen, parent, adult := playground.Citizens[0], playground.Citizens[1], playground.Citizens[2]
+	parent.HasEnergy = cfg.CreateBabyEnergy
+	parent.CreateBaby()
+	child := parent.Children[0]
+	citizen.HasEnergy, parent.HasEnergy, adult.HasEnergy, child.HasEnergy = cfg.RequiredEnergy, 0, 0, 0
+
+	helped := map[engine.PersonID]uint{}
+	for _, action := range strategy.HandleFood(playground.Observe(citizen), engine.Food{Amount: cfg.RequiredEnergy * 4}) {
+		helped[action.Destination] += action.Amount
+	}
+	if helped[child.ID()] != cfg.RequiredEnergy || helped[parent.ID()] != 0 || helped[adult.ID()] != 0 {
+		t.Errorf("fed the child with %d, the parent with %d, the adult with %d",
+			helped[child.ID()], helped[parent.ID()], helped[adult.ID()])
+	}
+}
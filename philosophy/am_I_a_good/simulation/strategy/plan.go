@@ -0,0 +1,177 @@
+package strategy
+
+import (
+	"sort"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// plan accumulates the actions of a strategy for a single food portion,
+// keeping track of the food which is not used, yet.
+type plan struct {
+	observation *engine.Observation
+	cfg         engine.Config
+	amount      uint
+	actions     []engine.Action
+
+	// eaten is the food of the portion eaten by the citizen itself.
+	eaten uint
+
+	// spent is the reserves spent on punishments.
+	spent uint
+}
+
+func newPlan(observation *engine.Observation, food engine.Food) *plan {
+	return &plan{
+		observation: observation,
+		cfg:         observation.Config(),
+		amount:      food.Amount,
+	}
+}
+
+func (p *plan) add(actionType engine.ActionType, amount uint, destination engine.PersonID, comment string) {
+	if amount > p.amount {
+		amount = p.amount
+	}
+	if amount == 0 {
+		return
+	}
+	p.actions = append(p.actions, engine.Action{
+		ActionType:  actionType,
+		Amount:      amount,
+		Destination: destination,
+		Comment:     comment,
+	})
+	p.amount -= amount
+	if actionType == engine.ActionTypeEat && destination == p.observation.Self.ID() {
+		p.eaten += amount
+	}
+}
+
+// selfPreservation eats enough to survive the week.
+func (p *plan) selfPreservation() {
+	toSurvive := int64(p.cfg.RequiredEnergy) - int64(p.observation.Self.HasEnergy())
+	if toSurvive <= 0 {
+		return
+	}
+	p.add(engine.ActionTypeEat, uint(toSurvive), p.observation.Self.ID(), "self-preservation")
+}
+
+// childPreservation feeds the own children, the most promising first.
+func (p *plan) childPreservation() {
+	children := p.observation.Children
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].TotalEnergy() > children[j].TotalEnergy()
+	})
+	for _, child := range children {
+		if p.amount == 0 {
+			break
+		}
+		toSurvive := int64(p.cfg.CreateBabyEnergy) - int64(child.HasEnergy())
+		if toSurvive <= 0 {
+			continue
+		}
+		p.add(engine.ActionTypeEat, uint(toSurvive), child.ID(), "child-preservation")
+	}
+}
+
+// altruism saves those hungry people among the given ones who
+// we can save (the closest to survival first) and who pass
+// the filter (nil filter passes everybody).
+func (p *plan) altruism(people []engine.PersonView, filter func(candidate engine.PersonView) bool) {
+	p.feedHungry(people, filter, engine.ActionTypeEat, "altruism")
+}
+
+// lending is the same as altruism, but lends the food instead of
+// giving it (see engine.Config.EnableLending).
+func (p *plan) lending(people []engine.PersonView, filter func(candidate engine.PersonView) bool) {
+	p.feedHungry(people, func(candidate engine.PersonView) bool {
+		if candidate.Citizen().ID() == p.observation.Self.ID() {
+			return false
+		}
+		return filter == nil || filter(candidate)
+	}, engine.ActionTypeLend, "lending")
+}
+
+// repayment repays the debts of the citizen, the oldest first.
+func (p *plan) repayment() {
+	for _, debt := range p.observation.Debts() {
+		p.add(engine.ActionTypeRepay, debt.Owed, debt.Creditor, "repayment")
+	}
+}
+
+func (p *plan) feedHungry(
+	people []engine.PersonView,
+	filter func(candidate engine.PersonView) bool,
+	actionType engine.ActionType,
+	comment string,
+) {
+	requiredEnergy := p.cfg.RequiredEnergy
+
+	var candidates []engine.PersonView
+	for _, candidate := range people {
+		if candidate.TotalEnergy() < requiredEnergy {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].TotalEnergy() > candidates[j].TotalEnergy()
+	})
+	for _, candidate := range candidates {
+		if p.amount == 0 {
+			break
+		}
+		if filter != nil && !filter(candidate) {
+			continue
+		}
+		toSurvive := requiredEnergy - candidate.TotalEnergy()
+		p.add(actionType, toSurvive, candidate.ID(), comment)
+	}
+}
+
+// punishment spends "fine" of the reserves (the energy and the hidden
+// food, not the portion) to punish each of up to maxPunishments random
+// citizens who pass the filter. Only the reserves which are not required
+// to survive the week are spent.
+func (p *plan) punishment(fine uint, maxPunishments uint, filter func(candidate engine.CitizenView) bool) {
+	self := p.observation.Self
+	eaten := self.HadEat() + p.eaten
+	if eaten > p.cfg.RequiredEnergy {
+		eaten = p.cfg.RequiredEnergy
+	}
+	if self.HasEnergy()+self.OwnsFood()+eaten < p.cfg.RequiredEnergy+p.spent {
+		return
+	}
+	spare := self.HasEnergy() + self.OwnsFood() + eaten - p.cfg.RequiredEnergy - p.spent
+	if reserves := self.HasEnergy() + self.OwnsFood() - p.spent; spare > reserves {
+		spare = reserves
+	}
+
+	citizens := p.observation.Citizens()
+	punishments := uint(0)
+	for _, candidateIdx := range p.observation.RandPerm(len(citizens)) {
+		if punishments >= maxPunishments || spare < fine {
+			break
+		}
+		candidate := citizens[candidateIdx].Citizen()
+		if candidate.ID() == self.ID() || !filter(candidate) {
+			continue
+		}
+		p.actions = append(p.actions, engine.Action{
+			ActionType:  engine.ActionTypePunish,
+			Amount:      fine,
+			Destination: candidate.ID(),
+			Comment:     "punishment",
+		})
+		spare -= fine
+		p.spent += fine
+		punishments++
+	}
+}
+
+// rest puts all the unused food to the citizen itself.
+func (p *plan) rest(actionType engine.ActionType, comment string) []engine.Action {
+	p.add(actionType, p.amount, p.observation.Self.ID(), comment)
+	return p.actions
+}
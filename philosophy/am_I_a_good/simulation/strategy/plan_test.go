@@ -0,0 +1,57 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+func TestPlanSelfPreservation(t *testing.T) {
+	const portion = 2000
+	for _, tc := range []struct {
+		name      string
+		hasEnergy uint
+		eaten     uint
+	}{
+		{name: "starving", hasEnergy: 0, eaten: 1000},
+		{name: "half-fed", hasEnergy: 400, eaten: 600},
+		{name: "fed", hasEnergy: 1000, eaten: 0},
+		{name: "rich", hasEnergy: 5000, eaten: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			playground := engine.NewPlayground(engine.DefaultConfig(), 1)
+			playground.AddCitizens(&DoNotTrust{}, 1)
+			citizen := playground.Citizens[0]
+			citizen.HasEnergy = tc.hasEnergy
+
+			p := newPlan(playground.Observe(citizen), engine.Food{Amount: portion})
+			p.selfPreservation()
+			actions := p.rest(engine.ActionTypeHide, "hiding")
+
+			eaten := uint(0)
+			for _, action := range actions {
+				if action.ActionType == engine.ActionTypeEat {
+					eaten += action.Amount
+				}
+			}
+			if eaten != tc.eaten {
+				t.Errorf("eaten %d, expected %d: %+v", eaten, tc.eaten, actions)
+			}
+			if p.amount != 0 {
+				t.Errorf("%d of the portion is left", p.amount)
+			}
+		})
+	}
+}
+
+// viewOf returns the view of the person as the observation shows it.
+func viewOf(t *testing.T, observation *engine.Observation, person *engine.Person) engine.PersonView {
+	t.Helper()
+	for _, view := range observation.People() {
+		if view.ID() == person.ID() {
+			return view
+		}
+	}
+	t.Fatalf("person #%d is not on the playground", person.ID())
+	return engine.PersonView{}
+}
@@ -0,0 +1,87 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// TitForTat helps the hungry who did not refuse this citizen the last
+// time they met (everybody is helped at the first meeting).
+type TitForTat struct{}
+
+func (strategy *TitForTat) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.childPreservation()
+	p.altruism(observation.HungryPeople(), func(candidate engine.PersonView) bool {
+		return observation.Relationship(candidate.Citizen()).TheirLastMove != engine.MoveRefused
+	})
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+// Grudger helps the hungry who never refused this citizen.
+type Grudger struct{}
+
+func (strategy *Grudger) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.childPreservation()
+	p.altruism(observation.HungryPeople(), func(candidate engine.PersonView) bool {
+		return observation.Relationship(candidate.Citizen()).RefusedMe == 0
+	})
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+// Pavlov ("win-stay, lose-shift") helps the hungry if the last moves of
+// this citizen and of the candidate towards each other were the same
+// (both helped or both refused), and refuses otherwise.
+type Pavlov struct{}
+
+func (strategy *Pavlov) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.childPreservation()
+	p.altruism(observation.HungryPeople(), func(candidate engine.PersonView) bool {
+		relationship := observation.Relationship(candidate.Citizen())
+		return relationship.TheirLastMove == relationship.MyLastMove
+	})
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+func requirePrivateMemory(cfg *engine.Config) error {
+	if !cfg.EnablePrivateMemory {
+		return fmt.Errorf("requires enable_private_memory")
+	}
+	return nil
+}
+
+func init() {
+	Register(Info{
+		Name:        "tit_for_tat",
+		Description: "helps the hungry unless they refused this citizen the last time (private memory)",
+		CheckWorld:  requirePrivateMemory,
+		Factory:     newWithoutParameters(func() engine.Strategy { return &TitForTat{} }),
+	})
+	Register(Info{
+		Name:        "grudger",
+		Description: "helps the hungry who never refused this citizen (private memory)",
+		CheckWorld:  requirePrivateMemory,
+		Factory:     newWithoutParameters(func() engine.Strategy { return &Grudger{} }),
+	})
+	Register(Info{
+		Name:        "pavlov",
+		Description: "helps the hungry if both the last moves between them and this citizen were the same (private memory)",
+		CheckWorld:  requirePrivateMemory,
+		Factory:     newWithoutParameters(func() engine.Strategy { return &Pavlov{} }),
+	})
+}
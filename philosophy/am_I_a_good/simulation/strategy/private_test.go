@@ -0,0 +1,97 @@
+package strategy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// checkDeserves lets the strategy play against EatTheRest for a while
+// and then checks that every citizen of the strategy helps exactly
+// the starving citizens who deserve it.
+func checkDeserves(
+	t *testing.T,
+	configure func(cfg *engine.Config),
+	strategy engine.Strategy,
+	deserves func(citizen, candidate *engine.Citizen) bool,
+) {
+	cfg := engine.DefaultConfig()
+	cfg.EnableChildren = false
+	cfg.EnableAging = false
+	cfg.AmountOfPortions = 20
+	configure(&cfg)
+	playground := engine.NewPlayground(cfg, 1)
+	playground.AddCitizens(strategy, 15)
+	playground.AddCitizens(&EatTheRest{}, 15)
+	for week := 0; week < 20; week++ {
+		playground.IterateWeek()
+	}
+
+	// everybody else starves and the citizen has enough food for all
+	var helped, refused uint
+	for _, citizen := range playground.Citizens {
+		if citizen.Strategy != strategy {
+			continue
+		}
+		for _, other := range playground.Citizens {
+			other.HasEnergy, other.OwnsFood, other.HadEat = 0, 0, 0
+		}
+		citizen.HasEnergy = cfg.RequiredEnergy
+		food := engine.Food{Amount: cfg.RequiredEnergy * uint(len(playground.Citizens))}
+
+		destinations := map[engine.PersonID]bool{}
+		for _, action := range strategy.HandleFood(playground.Observe(citizen), food) {
+			destinations[action.Destination] = true
+		}
+		for _, other := range playground.Citizens {
+			if other == citizen {
+				continue
+			}
+			expected := deserves(citizen, other)
+			if destinations[other.ID()] != expected {
+				t.Errorf("helps: %v, expected %v", destinations[other.ID()], expected)
+			}
+			if expected {
+				helped++
+			} else {
+				refused++
+			}
+		}
+	}
+	if helped == 0 || refused == 0 {
+		t.Errorf("the memory is not diverse enough for the test: helped %d, refused %d", helped, refused)
+	}
+}
+
+func TestPrivateMemoryStrategies(t *testing.T) {
+	for _, tc := range []struct {
+		strategy engine.Strategy
+		deserves func(engine.Relationship) bool
+	}{
+		{
+			strategy: &TitForTat{},
+			deserves: func(relationship engine.Relationship) bool { return relationship.TheirLastMove != engine.MoveRefused },
+		},
+		{
+			strategy: &Grudger{},
+			deserves: func(relationship engine.Relationship) bool { return relationship.RefusedMe == 0 },
+		},
+		{
+			strategy: &Pavlov{},
+			deserves: func(relationship engine.Relationship) bool {
+				return relationship.TheirLastMove == relationship.MyLastMove
+			},
+		},
+	} {
+		t.Run(fmt.Sprintf("%T", tc.strategy), func(t *testing.T) {
+			checkDeserves(t,
+				func(cfg *engine.Config) { cfg.EnablePrivateMemory = true },
+				tc.strategy,
+				func(citizen, candidate *engine.Citizen) bool {
+					return tc.deserves(citizen.Relationship(candidate))
+				},
+			)
+		})
+	}
+}
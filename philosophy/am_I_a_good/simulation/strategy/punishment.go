@@ -0,0 +1,83 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// Punisher helps the hungry who were not greedy the last time and
+// spends Fine of its spare energy or hidden food to punish each of up
+// to MaxPunishments random citizens who were greedy the last time.
+// If PunishNonPunishers is set, it also punishes those who did not
+// punish the greedy.
+type Punisher struct {
+	Fine               uint
+	MaxPunishments     uint
+	PunishNonPunishers bool
+}
+
+func (strategy *Punisher) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.childPreservation()
+	p.altruism(observation.HungryPeople(), func(candidate engine.PersonView) bool {
+		return !candidate.Citizen().SpottedAsGreedyLastTime()
+	})
+	p.punishment(strategy.Fine, strategy.MaxPunishments, func(candidate engine.CitizenView) bool {
+		if candidate.SpottedAsGreedyLastTime() {
+			return true
+		}
+		return strategy.PunishNonPunishers && candidate.SpottedAsNonPunisherLastTime()
+	})
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+func newPunisher(punishNonPunishers bool) func(Parameters) (engine.Strategy, error) {
+	return func(params Parameters) (engine.Strategy, error) {
+		fine := params["fine"]
+		if fine <= 0 || fine != float64(uint(fine)) {
+			return nil, fmt.Errorf("fine should be a positive integer, but it is %f", fine)
+		}
+		maxPunishments := params["max_punishments"]
+		if maxPunishments < 0 || maxPunishments != float64(uint(maxPunishments)) {
+			return nil, fmt.Errorf("max_punishments should be a non-negative integer, but it is %f", maxPunishments)
+		}
+		return &Punisher{
+			Fine:               uint(fine),
+			MaxPunishments:     uint(maxPunishments),
+			PunishNonPunishers: punishNonPunishers,
+		}, nil
+	}
+}
+
+func requirePunishment(cfg *engine.Config) error {
+	if !cfg.EnablePunishment {
+		return fmt.Errorf("requires enable_punishment")
+	}
+	return nil
+}
+
+func init() {
+	punisherParameters := []Parameter{
+		{Name: "fine", Description: "the spare energy or hidden food spent on a single punishment", Default: 100},
+		{Name: "max_punishments", Description: "the maximal amount of punishments per food portion", Default: 1},
+	}
+	Register(Info{
+		Name:        "punish_greedy",
+		Description: "helps the hungry who were not greedy the last time and punishes those who were (punishment)",
+		Parameters:  punisherParameters,
+		CheckWorld:  requirePunishment,
+		Factory:     newPunisher(false),
+	})
+	Register(Info{
+		Name:        "punish_non_punishers",
+		Description: "the same as punish_greedy, but also punishes those who did not punish the greedy (punishment)",
+		Parameters:  punisherParameters,
+		CheckWorld:  requirePunishment,
+		Factory:     newPunisher(true),
+	})
+}
@@ -0,0 +1,76 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+func TestPunisher(t *testing.T) {
+	const fine = 300
+	for _, tc := range []struct {
+		name               string
+		spare              int64
+		punishNonPunishers bool
+		candidates         []int
+		punishments        int
+	}{
+		{name: "the greedy", spare: 700, candidates: []int{1}, punishments: 1},
+		{name: "the greedy and the non-punishers", spare: 700, punishNonPunishers: true, candidates: []int{1, 2}, punishments: 2},
+		{name: "within the spare reserves", spare: 500, punishNonPunishers: true, candidates: []int{1, 2}, punishments: 1},
+		{name: "nothing to spare", spare: -100, punishNonPunishers: true, candidates: []int{1, 2}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := engine.DefaultConfig()
+			cfg.EnablePunishment = true
+			strategy := &Punisher{Fine: fine, MaxPunishments: 5, PunishNonPunishers: tc.punishNonPunishers}
+			playground := engine.NewPlayground(cfg, 1)
+			playground.AddCitizens(strategy, 1)
+			playground.AddCitizens(&EatTheRest{}, 3)
+			citizen, greedy, nonPunisher := playground.Citizens[0], playground.Citizens[1], playground.Citizens[2]
+			for _, other := range playground.Citizens {
+				other.HasEnergy, other.OwnsFood, other.HadEat = cfg.RequiredEnergy, 0, 0
+			}
+			greedy.SpottedAsGreedyLastTime = true
+			nonPunisher.SpottedAsNonPunisherLastTime = true
+			citizen.HasEnergy = uint(int64(cfg.RequiredEnergy) + tc.spare)
+			food := engine.Food{Amount: 100}
+
+			used, spent := uint(0), uint(0)
+			punished := map[engine.PersonID]bool{}
+			for _, action := range strategy.HandleFood(playground.Observe(citizen), food) {
+				if action.ActionType != engine.ActionTypePunish {
+					used += action.Amount
+					continue
+				}
+				if action.Amount != fine {
+					t.Errorf("punished for %d instead of %d", action.Amount, fine)
+				}
+				spent += action.Amount
+				punished[action.Destination] = true
+			}
+			if used != food.Amount {
+				t.Errorf("used %d of the portion of %d", used, food.Amount)
+			}
+			if int64(spent) > tc.spare && spent > 0 {
+				t.Errorf("spent %d having only %d to spare", spent, tc.spare)
+			}
+
+			if len(punished) != tc.punishments {
+				t.Errorf("punished %d citizens instead of %d", len(punished), tc.punishments)
+			}
+			for _, idx := range tc.candidates {
+				delete(punished, playground.Citizens[idx].ID())
+			}
+			if len(punished) != 0 {
+				t.Errorf("punished %d citizens who are not the candidates", len(punished))
+			}
+		})
+	}
+
+	for _, params := range []Parameters{{"fine": 0}, {"fine": 1.5}, {"max_punishments": -1}} {
+		if _, err := New("punish_greedy", params); err == nil {
+			t.Errorf("expected an error for %v", params)
+		}
+	}
+}
@@ -0,0 +1,407 @@
+package strategy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// QAction is a choice of the QLearner of what to do with a food portion.
+// Every choice first secures the survival of the citizen and then feeds
+// its children; they differ in what is done with the rest.
+type QAction uint
+
+const (
+	QActionEat = QAction(iota)
+	QActionHide
+	QActionShareWithDeserving
+	QActionShareWithEverybody
+
+	endOfQAction
+)
+
+func (action QAction) String() string {
+	switch action {
+	case QActionEat:
+		return "eat"
+	case QActionHide:
+		return "hide"
+	case QActionShareWithDeserving:
+		return "share_with_deserving"
+	case QActionShareWithEverybody:
+		return "share_with_everybody"
+	}
+	return "unknown"
+}
+
+// qObservation is what the QLearner sees when deciding. Every field is
+// bucketed to keep the Q-table small.
+type qObservation struct {
+	// Energy is the own energy in weeks of RequiredEnergy: 0, 1, 2-3, 4+.
+	Energy uint
+
+	// Food is the food portion in weeks of RequiredEnergy: 0, 1, 2+.
+	Food uint
+
+	HasHungryChildren bool
+
+	// HungryOthers is the amount of the other hungry people: 0, 1-3, 4+.
+	HungryOthers uint
+
+	// MostHungryDeserve is true if the most of the other hungry people
+	// have a good reputation (see Reciprocity with Kindness 2).
+	MostHungryDeserve bool
+
+	// SpottedAsGreedy is the own reputation.
+	SpottedAsGreedy bool
+}
+
+const (
+	qEnergyBuckets       = 4
+	qFoodBuckets         = 3
+	qHungryOthersBuckets = 3
+
+	// QStates is the amount of distinct observations.
+	QStates = qEnergyBuckets * qFoodBuckets * 2 * qHungryOthersBuckets * 2 * 2
+)
+
+func (observation qObservation) index() uint {
+	index := observation.Energy
+	index = index*qFoodBuckets + observation.Food
+	index = index*2 + boolToUint(observation.HasHungryChildren)
+	index = index*qHungryOthersBuckets + observation.HungryOthers
+	index = index*2 + boolToUint(observation.MostHungryDeserve)
+	index = index*2 + boolToUint(observation.SpottedAsGreedy)
+	return index
+}
+
+func boolToUint(b bool) uint {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// QTable is the estimated value of every QAction in every observation.
+type QTable struct {
+	Values [QStates][endOfQAction]float64
+}
+
+// LoadQTable reads a table written by QTable.Save.
+func LoadQTable(r io.Reader) (*QTable, error) {
+	table := &QTable{}
+	if err := json.NewDecoder(r).Decode(table); err != nil {
+		return nil, fmt.Errorf("unable to decode the Q-table: %w", err)
+	}
+	return table, nil
+}
+
+// Save writes the table as JSON.
+func (table *QTable) Save(w io.Writer) error {
+	return json.NewEncoder(w).Encode(table)
+}
+
+// less compares the tables lexicographically.
+func (table *QTable) less(other *QTable) bool {
+	for state := range table.Values {
+		for action := range table.Values[state] {
+			if table.Values[state][action] != other.Values[state][action] {
+				return table.Values[state][action] < other.Values[state][action]
+			}
+		}
+	}
+	return false
+}
+
+func (table *QTable) bestAction(state uint) QAction {
+	best := QAction(0)
+	for action := QAction(1); action < endOfQAction; action++ {
+		if table.Values[state][action] > table.Values[state][best] {
+			best = action
+		}
+	}
+	return best
+}
+
+// qExperience is the last decision of a citizen, which is rewarded
+// on its next decision or death. It is the state of the citizen
+// (see engine.StatefulStrategy).
+type qExperience struct {
+	learning *qPlaygroundState
+	decided  bool
+	state    uint
+	action   QAction
+	weekID   uint
+}
+
+// qPlaygroundState is the learning state of a QLearner on a single
+// playground; playgrounds are played in parallel, so each one learns
+// its own copy of the table to stay reproducible.
+type qPlaygroundState struct {
+	playground engine.PlaygroundID
+	table      *QTable
+
+	// citizens is the amount of the citizens learning the table; when
+	// the last one finishes, the table waits for Commit in
+	// QLearner.finished.
+	citizens uint
+}
+
+// QLearner is a tabular Q-learning strategy. It observes its own energy,
+// the food portion, the hunger of its children and of the others and
+// the reputations, and chooses a QAction.
+//
+// The reward is the size of the household (the citizen and its
+// children) for every week it survives; the death ends the episode.
+//
+// In the training mode every playground learns from a copy of Table,
+// and Commit puts the learned values back into Table, so that the
+// learning continues across runs. Otherwise Table is only read.
+type QLearner struct {
+	Table        *QTable
+	Training     bool
+	LearningRate float64
+	Discount     float64
+
+	// Exploration is the probability to choose a random action.
+	Exploration float64
+
+	locker      sync.Mutex
+	playgrounds map[engine.PlaygroundID]*qPlaygroundState
+	finished    []*QTable
+}
+
+var _ engine.CitizenStateFinalizer = (*QLearner)(nil)
+
+func (strategy *QLearner) NewCitizenState(observation *engine.Observation) any {
+	if !strategy.Training {
+		return nil
+	}
+	return &qExperience{learning: strategy.playgroundState(observation.Playground)}
+}
+
+// FinishCitizenState ends the episode of a dead citizen.
+func (strategy *QLearner) FinishCitizenState(state any, died bool) {
+	experience, ok := state.(*qExperience)
+	if !ok {
+		return
+	}
+	if experience.decided && died {
+		strategy.update(experience.learning.table, experience, 0)
+	}
+	strategy.releasePlaygroundState(experience.learning)
+}
+
+func (strategy *QLearner) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	state := strategy.observe(observation, food).index()
+
+	table := strategy.Table
+	experience, learning := observation.StrategyState.(*qExperience)
+	if learning {
+		table = experience.learning.table
+		strategy.learn(observation, experience, state)
+	}
+
+	action := table.bestAction(state)
+	if strategy.Exploration > 0 && observation.RandFloat64() < strategy.Exploration {
+		action = QAction(observation.RandUintn(uint(endOfQAction)))
+	}
+
+	if learning {
+		experience.decided = true
+		experience.state, experience.action, experience.weekID = state, action, observation.WeekID
+	}
+
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.childPreservation()
+	switch action {
+	case QActionHide:
+		return p.rest(engine.ActionTypeHide, "hiding")
+	case QActionShareWithDeserving:
+		p.altruism(observation.HungryPeople(), (&Reciprocity{Kindness: 2}).deserves)
+	case QActionShareWithEverybody:
+		p.altruism(observation.HungryPeople(), nil)
+	}
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+func (strategy *QLearner) observe(observation *engine.Observation, food engine.Food) qObservation {
+	cfg := observation.Config()
+	result := qObservation{
+		Food:            min(food.Amount/cfg.RequiredEnergy, qFoodBuckets-1),
+		SpottedAsGreedy: observation.Self.Citizen().SpottedAsGreedyLastTime(),
+	}
+	switch weeks := observation.Self.HasEnergy() / cfg.RequiredEnergy; {
+	case weeks < 2:
+		result.Energy = weeks
+	case weeks < 4:
+		result.Energy = 2
+	default:
+		result.Energy = 3
+	}
+	for _, child := range observation.Children {
+		if child.HasEnergy() < cfg.CreateBabyEnergy {
+			result.HasHungryChildren = true
+			break
+		}
+	}
+	hungry, deserving := uint(0), uint(0)
+	kindMirror := &Reciprocity{Kindness: 2}
+	for _, person := range observation.HungryPeople() {
+		if person.Citizen().ID() == observation.Self.ID() {
+			continue
+		}
+		hungry++
+		if kindMirror.deserves(person) {
+			deserving++
+		}
+	}
+	switch {
+	case hungry == 0:
+		result.HungryOthers = 0
+	case hungry <= 3:
+		result.HungryOthers = 1
+	default:
+		result.HungryOthers = 2
+	}
+	result.MostHungryDeserve = hungry > 0 && deserving*2 > hungry
+	return result
+}
+
+func (strategy *QLearner) playgroundState(playground engine.PlaygroundID) *qPlaygroundState {
+	strategy.locker.Lock()
+	defer strategy.locker.Unlock()
+	if strategy.playgrounds == nil {
+		strategy.playgrounds = map[engine.PlaygroundID]*qPlaygroundState{}
+	}
+	state := strategy.playgrounds[playground]
+	if state == nil {
+		table := *strategy.Table
+		state = &qPlaygroundState{
+			playground: playground,
+			table:      &table,
+		}
+		strategy.playgrounds[playground] = state
+	}
+	state.citizens++
+	return state
+}
+
+// releasePlaygroundState is called when a citizen stops learning
+// the table of the playground (see engine.CitizenStateFinalizer, which
+// is also called for the alive citizens at the end of a try).
+func (strategy *QLearner) releasePlaygroundState(state *qPlaygroundState) {
+	strategy.locker.Lock()
+	defer strategy.locker.Unlock()
+	state.citizens--
+	if state.citizens > 0 {
+		return
+	}
+	delete(strategy.playgrounds, state.playground)
+	strategy.finished = append(strategy.finished, state.table)
+}
+
+// learn rewards the previous decision of the citizen.
+func (strategy *QLearner) learn(observation *engine.Observation, experience *qExperience, state uint) {
+	if !experience.decided {
+		return
+	}
+	reward := float64(observation.WeekID-experience.weekID) * float64(1+len(observation.Children))
+	table := experience.learning.table
+	strategy.update(table, experience, reward+strategy.Discount*table.Values[state][table.bestAction(state)])
+}
+
+func (strategy *QLearner) update(table *QTable, experience *qExperience, target float64) {
+	value := &table.Values[experience.state][experience.action]
+	*value += strategy.LearningRate * (target - *value)
+}
+
+// Commit puts the values learned on the playgrounds since the previous
+// Commit into Table (averaging them if there were multiple playgrounds).
+func (strategy *QLearner) Commit() {
+	strategy.locker.Lock()
+	defer strategy.locker.Unlock()
+	tables := strategy.finished
+	for _, state := range strategy.playgrounds {
+		tables = append(tables, state.table)
+	}
+	if len(tables) == 0 {
+		return
+	}
+	// the playgrounds are played in parallel, so the tables are summed up
+	// in the order of their values to get the same average every time
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].less(tables[j])
+	})
+	table := &QTable{}
+	for _, learned := range tables {
+		for stateIdx := range table.Values {
+			for action := range table.Values[stateIdx] {
+				table.Values[stateIdx][action] += learned.Values[stateIdx][action] / float64(len(tables))
+			}
+		}
+	}
+	strategy.Table = table
+	strategy.playgrounds = nil
+	strategy.finished = nil
+}
+
+// Policy returns how many observations prefer every QAction.
+func (strategy *QLearner) Policy() map[QAction]uint {
+	result := map[QAction]uint{}
+	for state := uint(0); state < QStates; state++ {
+		if strategy.Table.Values[state] == ([endOfQAction]float64{}) {
+			continue
+		}
+		result[strategy.Table.bestAction(state)]++
+	}
+	return result
+}
+
+func newQLearner(params Parameters) (engine.Strategy, error) {
+	strategy := &QLearner{
+		Table:        &QTable{},
+		LearningRate: params["learning_rate"],
+		Discount:     params["discount"],
+		Exploration:  params["exploration"],
+	}
+	if strategy.LearningRate < 0 || strategy.LearningRate > 1 {
+		return nil, fmt.Errorf("learning_rate should be within [0, 1], but it is %f", strategy.LearningRate)
+	}
+	if strategy.Discount < 0 || strategy.Discount >= 1 {
+		return nil, fmt.Errorf("discount should be within [0, 1), but it is %f", strategy.Discount)
+	}
+	if strategy.Exploration < 0 || strategy.Exploration > 1 {
+		return nil, fmt.Errorf("exploration should be within [0, 1], but it is %f", strategy.Exploration)
+	}
+	switch training := params["training"]; training {
+	case 0:
+	case 1:
+		strategy.Training = true
+	default:
+		return nil, fmt.Errorf("training should be 0 or 1, but it is %f", training)
+	}
+	return strategy, nil
+}
+
+func init() {
+	Register(Info{
+		Name:        "q_learning",
+		Description: "learns whether to eat, hide or share the rest of the food by tabular Q-learning (see runner -train)",
+		Parameters: []Parameter{
+			{Name: "training", Description: "1 to learn during the run, 0 to only play the learned table", Default: 0},
+			{Name: "learning_rate", Description: "how fast the estimations follow the rewards", Default: 0.1},
+			{Name: "discount", Description: "the weight of the future rewards", Default: 0.95},
+			{Name: "exploration", Description: "the probability to choose a random action", Default: 0.05},
+		},
+		Factory: newQLearner,
+	})
+}
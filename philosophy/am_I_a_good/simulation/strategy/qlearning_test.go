@@ -0,0 +1,162 @@
+package strategy
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+func TestQTableSaveLoad(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		fill func(table *QTable)
+	}{
+		{
+			name: "empty",
+			fill: func(table *QTable) {},
+		},
+		{
+			name: "single value",
+			fill: func(table *QTable) {
+				table.Values[QStates-1][endOfQAction-1] = 1
+			},
+		},
+		{
+			name: "every value",
+			fill: func(table *QTable) {
+				for state := range table.Values {
+					for action := range table.Values[state] {
+						table.Values[state][action] = float64(state)*1.5 - float64(action)/3
+					}
+				}
+			},
+		},
+		{
+			name: "extreme values",
+			fill: func(table *QTable) {
+				table.Values[0][0] = math.MaxFloat64
+				table.Values[0][1] = -math.MaxFloat64
+				table.Values[1][0] = math.SmallestNonzeroFloat64
+				table.Values[1][1] = 0.1 + 0.2
+				table.Values[2][0] = math.Copysign(0, -1)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			table := &QTable{}
+			tc.fill(table)
+
+			var buf bytes.Buffer
+			if err := table.Save(&buf); err != nil {
+				t.Fatal(err)
+			}
+			loaded, err := LoadQTable(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for state := range table.Values {
+				for action := range table.Values[state] {
+					if loaded.Values[state][action] != table.Values[state][action] {
+						t.Fatalf("value of %v in state %d: %v != %v",
+							QAction(action), state, loaded.Values[state][action], table.Values[state][action])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLoadQTableInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "truncated", input: `{"Values": [[1, 2`},
+		{name: "not a table", input: `{"Values": 5}`},
+		{name: "not a number", input: `{"Values": [["a"]]}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := LoadQTable(strings.NewReader(tc.input)); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func runQLearner(learner *QLearner, parallel int) {
+	cfg := engine.DefaultConfig()
+	cfg.AmountOfPortions = 15
+	experiment := &engine.Experiment{
+		Config:   cfg,
+		Tries:    4,
+		Weeks:    100,
+		Seed:     1,
+		Parallel: parallel,
+		Populate: func(playground *engine.Playground) {
+			playground.AddCitizens(learner, 10)
+			playground.AddCitizens(&EatTheRest{}, 10)
+		},
+	}
+	experiment.Run()
+}
+
+func TestQLearnerTraining(t *testing.T) {
+	newLearner := func() *QLearner {
+		s, err := New("q_learning", Parameters{"training": 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s.(*QLearner)
+	}
+
+	learner := newLearner()
+	runQLearner(learner, 1)
+	if len(learner.playgrounds) != 0 {
+		t.Errorf("%d playgrounds are kept after their tries", len(learner.playgrounds))
+	}
+	if len(learner.finished) != 4 {
+		t.Fatalf("%d tables wait for Commit instead of 4", len(learner.finished))
+	}
+	learner.Commit()
+	if len(learner.finished) != 0 {
+		t.Errorf("%d tables are kept after Commit", len(learner.finished))
+	}
+	if *learner.Table == (QTable{}) {
+		t.Fatalf("nothing was learned")
+	}
+
+	committed := *learner.Table
+	learner.Commit()
+	if *learner.Table != committed {
+		t.Errorf("Commit without new tries changed the table")
+	}
+
+	parallel := newLearner()
+	runQLearner(parallel, 4)
+	parallel.Commit()
+	if *parallel.Table != committed {
+		t.Errorf("the table learned in parallel differs from the table learned sequentially")
+	}
+}
+
+func TestQLearnerPlaying(t *testing.T) {
+	s, err := New("q_learning", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	learner := s.(*QLearner)
+	if learner.Training {
+		t.Fatalf("the learner trains by default")
+	}
+	learner.Table.Values[0][QActionHide] = 1
+	table := *learner.Table
+	runQLearner(learner, 0)
+	learner.Commit()
+	if *learner.Table != table || len(learner.playgrounds) != 0 {
+		t.Errorf("the learner learned without training")
+	}
+}
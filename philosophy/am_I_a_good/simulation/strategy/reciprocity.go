@@ -0,0 +1,90 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// Reciprocity helps the hungry who saved enough people compared to how
+// many times they were saved themselves:
+//
+//	SavedPeople*Kindness + Forgiveness >= WasSavedTimes
+//
+// The check is made before the help, so a candidate who saved n people
+// is helped at most Kindness*n+Forgiveness+1 times (rounded down).
+type Reciprocity struct {
+	Kindness    float64
+	Forgiveness float64
+
+	// DecayWindowInWeeks if non-zero makes only the last
+	// DecayWindowInWeeks weeks count.
+	DecayWindowInWeeks uint
+}
+
+func (strategy *Reciprocity) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.childPreservation()
+	p.altruism(observation.HungryPeople(), strategy.deserves)
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+func (strategy *Reciprocity) deserves(candidate engine.PersonView) bool {
+	savedPeople := candidate.Citizen().SavedPeople()
+	wasSavedTimes := candidate.Citizen().WasSavedTimes()
+	if strategy.DecayWindowInWeeks > 0 {
+		savedPeople = candidate.Citizen().SavedPeopleInLastWeeks(strategy.DecayWindowInWeeks)
+		wasSavedTimes = candidate.Citizen().WasSavedTimesInLastWeeks(strategy.DecayWindowInWeeks)
+	}
+	return float64(savedPeople)*strategy.Kindness+strategy.Forgiveness >= float64(wasSavedTimes)
+}
+
+func newReciprocity(params Parameters) (engine.Strategy, error) {
+	strategy := &Reciprocity{
+		Kindness:    params["kindness"],
+		Forgiveness: params["forgiveness"],
+	}
+	if strategy.Kindness < 0 {
+		return nil, fmt.Errorf("kindness should not be negative, but it is %f", strategy.Kindness)
+	}
+	decayWindow := params["decay_window_in_weeks"]
+	if decayWindow < 0 || decayWindow != float64(uint(decayWindow)) {
+		return nil, fmt.Errorf("decay_window_in_weeks should be a non-negative integer, but it is %f", decayWindow)
+	}
+	strategy.DecayWindowInWeeks = uint(decayWindow)
+	return strategy, nil
+}
+
+// reciprocityPreset returns a Factory of Reciprocity with the fixed kindness.
+func reciprocityPreset(kindness float64) func(Parameters) (engine.Strategy, error) {
+	return func(Parameters) (engine.Strategy, error) {
+		return newReciprocity(Parameters{"kindness": kindness})
+	}
+}
+
+func init() {
+	Register(Info{
+		Name:        "reciprocity",
+		Description: "helps the hungry who saved n people at most kindness*n+forgiveness times",
+		Parameters: []Parameter{
+			{Name: "kindness", Description: "how many times to help per person saved by the candidate", Default: 1},
+			{Name: "forgiveness", Description: "how many times to help a candidate who never saved anybody", Default: 0},
+			{Name: "decay_window_in_weeks", Description: "count only the last weeks (0 to count the whole life)", Default: 0},
+		},
+		Factory: newReciprocity,
+	})
+	Register(Info{
+		Name:        "trust_mirror",
+		Description: "helps the hungry who saved at least as many people as they were saved themselves (reciprocity with kindness=1)",
+		Factory:     reciprocityPreset(1),
+	})
+	Register(Info{
+		Name:        "trust_kind_mirror",
+		Description: "helps the hungry who saved at least half as many people as they were saved themselves (reciprocity with kindness=2)",
+		Factory:     reciprocityPreset(2),
+	})
+}
@@ -0,0 +1,65 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+func TestReciprocityDeserves(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		params        Parameters
+		savedPeople   uint
+		wasSavedTimes uint
+		deserves      bool
+	}{
+		{name: "stranger", deserves: true},
+		{name: "ungrateful", wasSavedTimes: 1, deserves: false},
+		{name: "forgiven", params: Parameters{"forgiveness": 1}, wasSavedTimes: 1, deserves: true},
+		{name: "mirror", savedPeople: 2, wasSavedTimes: 2, deserves: true},
+		{name: "mirror exhausted", savedPeople: 2, wasSavedTimes: 3, deserves: false},
+		{name: "kind", params: Parameters{"kindness": 2}, savedPeople: 2, wasSavedTimes: 4, deserves: true},
+		{name: "kind exhausted", params: Parameters{"kindness": 2}, savedPeople: 2, wasSavedTimes: 5, deserves: false},
+		{name: "fractional kindness", params: Parameters{"kindness": 0.5}, savedPeople: 3, wasSavedTimes: 2, deserves: false},
+		{name: "forgotten", params: Parameters{"decay_window_in_weeks": 4}, wasSavedTimes: 5, deserves: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := New("reciprocity", tc.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			playground := engine.NewPlayground(engine.DefaultConfig(), 1)
+			playground.AddCitizens(s, 1)
+			candidate := playground.Citizens[0]
+			candidate.SavedPeople = tc.savedPeople
+			candidate.WasSavedTimes = tc.wasSavedTimes
+
+			if deserves := s.(*Reciprocity).deserves(viewOf(t, playground.Observe(candidate), &candidate.Person)); deserves != tc.deserves {
+				t.Errorf("deserves: %v, expected %v", deserves, tc.deserves)
+			}
+		})
+	}
+}
+
+func TestReciprocityParameters(t *testing.T) {
+	for _, params := range []Parameters{
+		{"kindness": -1},
+		{"decay_window_in_weeks": -1},
+		{"decay_window_in_weeks": 1.5},
+	} {
+		if _, err := New("reciprocity", params); err == nil {
+			t.Errorf("expected an error for %v", params)
+		}
+	}
+
+	for name, kindness := range map[string]float64{"trust_mirror": 1, "trust_kind_mirror": 2} {
+		s, err := New(name, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reciprocity := s.(*Reciprocity); reciprocity.Kindness != kindness || reciprocity.Forgiveness != 0 {
+			t.Errorf("%s is %+v, expected the kindness %f", name, reciprocity, kindness)
+		}
+	}
+}
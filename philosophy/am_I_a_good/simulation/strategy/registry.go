@@ -0,0 +1,173 @@
+// Package strategy contains the strategies of the essay experiments and
+// the registry of strategies by name.
+//
+// A strategy implemented in another package becomes available to scenarios,
+// tournaments and reports by calling Register from the init() of that
+// package and importing it (e.g. with a blank import) into the binary.
+package strategy
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// Parameters are the values of the parameters of a strategy by their names.
+type Parameters map[string]float64
+
+// String returns a stable human-readable representation,
+// e.g. "kindness=2,forgiveness=1".
+func (params Parameters) String() string {
+	var names []string
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var words []string
+	for _, name := range names {
+		words = append(words, name+"="+strconv.FormatFloat(params[name], 'g', -1, 64))
+	}
+	return strings.Join(words, ",")
+}
+
+// Parameter describes a parameter of a strategy.
+type Parameter struct {
+	Name        string
+	Description string
+	Default     float64
+}
+
+// Info describes a registered strategy.
+type Info struct {
+	// Name is the stable identifier used in scenario files, CLI flags
+	// and reports.
+	Name string
+
+	Description string
+	Parameters  []Parameter
+
+	// Suicidal means the strategy may let its own citizen starve while
+	// having enough food, so it requires engine.Config.AllowSuicidalStrategies.
+	Suicidal bool
+
+	// CheckWorld if set returns an error if the strategy cannot be
+	// played in the world (e.g. it requires an optional model).
+	CheckWorld func(cfg *engine.Config) error
+
+	// Factory creates a new instance of the strategy. The parameters
+	// are already validated and contain every declared parameter.
+	Factory func(params Parameters) (engine.Strategy, error)
+}
+
+var (
+	registryLocker sync.RWMutex
+	registry       = map[string]*Info{}
+)
+
+// Register adds a strategy to the registry. It is supposed to be called
+// from init() of the package implementing the strategy, so that the
+// strategy becomes available by importing the package.
+func Register(info Info) {
+	if info.Name == "" || info.Factory == nil {
+		panic(fmt.Sprintf("invalid strategy info: %#+v", info))
+	}
+	registryLocker.Lock()
+	defer registryLocker.Unlock()
+	if _, ok := registry[info.Name]; ok {
+		panic(fmt.Sprintf("strategy '%s' is already registered", info.Name))
+	}
+	registry[info.Name] = &info
+}
+
+// Get returns the description of the registered strategy.
+func Get(name string) (*Info, error) {
+	registryLocker.RLock()
+	defer registryLocker.RUnlock()
+	info, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy '%s', known strategies: %v", name, namesLocked())
+	}
+	return info, nil
+}
+
+// New returns a new instance of the strategy with the given name.
+// Missing parameters take their default values.
+func New(name string, params Parameters) (engine.Strategy, error) {
+	info, err := Get(name)
+	if err != nil {
+		return nil, err
+	}
+	fullParams, err := info.WithDefaults(params)
+	if err != nil {
+		return nil, err
+	}
+	return info.Factory(fullParams)
+}
+
+// WithDefaults returns the parameters complemented by the default values,
+// or an error if there is an unknown parameter.
+func (info *Info) WithDefaults(params Parameters) (Parameters, error) {
+	result := Parameters{}
+	for _, param := range info.Parameters {
+		result[param.Name] = param.Default
+	}
+	for name, value := range params {
+		if _, ok := result[name]; !ok {
+			return nil, fmt.Errorf("strategy '%s' has no parameter '%s'", info.Name, name)
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+// Supports returns an error if the strategy cannot be played
+// in the world.
+func (info *Info) Supports(cfg *engine.Config) error {
+	if info.Suicidal && !cfg.AllowSuicidalStrategies {
+		return fmt.Errorf("strategy '%s' may starve its own citizens, it requires allow_suicidal_strategies", info.Name)
+	}
+	if info.CheckWorld != nil {
+		if err := info.CheckWorld(cfg); err != nil {
+			return fmt.Errorf("strategy '%s': %w", info.Name, err)
+		}
+	}
+	return nil
+}
+
+// Names returns the names of all the registered strategies.
+func Names() []string {
+	registryLocker.RLock()
+	defer registryLocker.RUnlock()
+	return namesLocked()
+}
+
+func namesLocked() []string {
+	var result []string
+	for name := range registry {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// All returns the descriptions of all the registered strategies
+// sorted by name.
+func All() []*Info {
+	var result []*Info
+	for _, name := range Names() {
+		info, _ := Get(name)
+		result = append(result, info)
+	}
+	return result
+}
+
+// newWithoutParameters returns a Factory for strategies without parameters.
+func newWithoutParameters(factory func() engine.Strategy) func(Parameters) (engine.Strategy, error) {
+	return func(Parameters) (engine.Strategy, error) {
+		return factory(), nil
+	}
+}
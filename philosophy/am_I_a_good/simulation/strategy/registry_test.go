@@ -0,0 +1,142 @@
+package strategy
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// parameterized remembers the parameters it was created with.
+type parameterized struct {
+	DoNotTrust
+	params Parameters
+}
+
+func init() {
+	Register(Info{
+		Name:        "test_parameterized",
+		Description: "a test strategy with parameters",
+		Parameters: []Parameter{
+			{Name: "kindness", Default: 1},
+			{Name: "forgiveness", Default: 0.5},
+		},
+		Factory: func(params Parameters) (engine.Strategy, error) {
+			if params["kindness"] < 0 {
+				return nil, fmt.Errorf("kindness should not be negative")
+			}
+			return &parameterized{params: params}, nil
+		},
+	})
+}
+
+func TestNew(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		params Parameters
+		result Parameters
+		error  string
+	}{
+		{name: "defaults", result: Parameters{"kindness": 1, "forgiveness": 0.5}},
+		{name: "override", params: Parameters{"kindness": 2}, result: Parameters{"kindness": 2, "forgiveness": 0.5}},
+		{name: "unknown parameter", params: Parameters{"greed": 1}, error: "has no parameter 'greed'"},
+		{name: "invalid value", params: Parameters{"kindness": -1}, error: "should not be negative"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := New("test_parameterized", tc.params)
+			switch {
+			case tc.error == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tc.error != "" && (err == nil || !strings.Contains(err.Error(), tc.error)):
+				t.Fatalf("expected an error containing '%s', got: %v", tc.error, err)
+			case tc.error != "":
+				return
+			}
+			if params := s.(*parameterized).params; !reflect.DeepEqual(params, tc.result) {
+				t.Errorf("created with %v, expected %v", params, tc.result)
+			}
+		})
+	}
+
+	if _, err := New("eat_everybody", nil); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+	a, _ := New("test_parameterized", nil)
+	b, _ := New("test_parameterized", nil)
+	if a == b {
+		t.Error("New should return a new instance every time")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		info Info
+	}{
+		{name: "no name", info: Info{Factory: newWithoutParameters(func() engine.Strategy { return &EatTheRest{} })}},
+		{name: "no factory", info: Info{Name: "test_no_factory"}},
+		{name: "duplicate", info: Info{Name: "eat_the_rest", Factory: newWithoutParameters(func() engine.Strategy { return &EatTheRest{} })}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected a panic")
+				}
+			}()
+			Register(tc.info)
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	names := Names()
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("the names are not sorted: %v", names)
+	}
+	all := All()
+	if len(all) != len(names) {
+		t.Fatalf("%d strategies, but %d names", len(all), len(names))
+	}
+	for idx, info := range all {
+		if info.Name != names[idx] || info.Description == "" {
+			t.Errorf("strategy #%d: %+v", idx, info)
+		}
+	}
+
+}
+
+func TestSupports(t *testing.T) {
+	for _, tc := range []struct {
+		strategy  string
+		configure func(cfg *engine.Config)
+		supported bool
+	}{
+		{strategy: "eat_the_rest", supported: true},
+		{strategy: "share_everything", supported: false},
+		{strategy: "share_everything", configure: func(cfg *engine.Config) { cfg.AllowSuicidalStrategies = true }, supported: true},
+		{strategy: "grudger", supported: false},
+		{strategy: "grudger", configure: func(cfg *engine.Config) { cfg.EnablePrivateMemory = true }, supported: true},
+	} {
+		cfg := engine.DefaultConfig()
+		if tc.configure != nil {
+			tc.configure(&cfg)
+		}
+		info, err := Get(tc.strategy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := info.Supports(&cfg); (err == nil) != tc.supported {
+			t.Errorf("%s: unexpected support (%v) of %+v", tc.strategy, err, cfg)
+		}
+	}
+}
+
+func TestParametersString(t *testing.T) {
+	params := Parameters{"kindness": 2, "forgiveness": 0.25, "anger": 1}
+	if s := params.String(); s != "anger=1,forgiveness=0.25,kindness=2" {
+		t.Errorf("unexpected string: %s", s)
+	}
+}
@@ -0,0 +1,159 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// ProbabilisticSharing eats enough to survive, feeds own children and
+// helps each hungry person with a probability. Every citizen has its
+// own probability drawn uniformly from
+// [ShareProbability-IndividualSpread, ShareProbability+IndividualSpread]
+// (clamped to [0, 1]) when it starts following the strategy.
+type ProbabilisticSharing struct {
+	ShareProbability float64
+	IndividualSpread float64
+}
+
+var _ engine.StatefulStrategy = (*ProbabilisticSharing)(nil)
+
+// sharingPropensity is the state of a ProbabilisticSharing citizen.
+type sharingPropensity struct {
+	shareProbability float64
+}
+
+func (strategy *ProbabilisticSharing) NewCitizenState(observation *engine.Observation) any {
+	probability := strategy.ShareProbability
+	if strategy.IndividualSpread > 0 {
+		probability += (observation.RandFloat64()*2 - 1) * strategy.IndividualSpread
+		probability = min(max(probability, 0), 1)
+	}
+	return &sharingPropensity{shareProbability: probability}
+}
+
+func (strategy *ProbabilisticSharing) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	propensity := observation.StrategyState.(*sharingPropensity)
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.childPreservation()
+	p.altruism(observation.HungryPeople(), func(candidate engine.PersonView) bool {
+		return observation.RandFloat64() < propensity.shareProbability
+	})
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+// Mixed plays strategy A with probability ProbabilityOfA and strategy B
+// otherwise: either for every food portion, or (if PerCitizen is set)
+// once for every citizen when it starts following the strategy.
+//
+// A and B should not be stateful (see engine.StatefulStrategy).
+type Mixed struct {
+	A, B           engine.Strategy
+	ProbabilityOfA float64
+	PerCitizen     bool
+}
+
+var _ engine.StatefulStrategy = (*Mixed)(nil)
+
+// mixedChoice is the state of a Mixed citizen.
+type mixedChoice struct {
+	playsA bool
+}
+
+// NewMixed returns a Mixed strategy or an error if it cannot be composed
+// of the given strategies.
+func NewMixed(a, b engine.Strategy, probabilityOfA float64, perCitizen bool) (*Mixed, error) {
+	for _, s := range []engine.Strategy{a, b} {
+		if _, ok := s.(engine.StatefulStrategy); ok {
+			return nil, fmt.Errorf("stateful strategy %T cannot be mixed", s)
+		}
+	}
+	if probabilityOfA < 0 || probabilityOfA > 1 {
+		return nil, fmt.Errorf("the probability should be within [0, 1], but it is %f", probabilityOfA)
+	}
+	return &Mixed{
+		A:              a,
+		B:              b,
+		ProbabilityOfA: probabilityOfA,
+		PerCitizen:     perCitizen,
+	}, nil
+}
+
+func (strategy *Mixed) NewCitizenState(observation *engine.Observation) any {
+	if !strategy.PerCitizen {
+		return nil
+	}
+	return &mixedChoice{playsA: observation.RandFloat64() < strategy.ProbabilityOfA}
+}
+
+func (strategy *Mixed) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	var playsA bool
+	if strategy.PerCitizen {
+		playsA = observation.StrategyState.(*mixedChoice).playsA
+	} else {
+		playsA = observation.RandFloat64() < strategy.ProbabilityOfA
+	}
+	if playsA {
+		return strategy.A.HandleFood(observation, food)
+	}
+	return strategy.B.HandleFood(observation, food)
+}
+
+func newProbabilisticSharing(params Parameters) (engine.Strategy, error) {
+	strategy := &ProbabilisticSharing{
+		ShareProbability: params["share_probability"],
+		IndividualSpread: params["individual_spread"],
+	}
+	if strategy.ShareProbability < 0 || strategy.ShareProbability > 1 {
+		return nil, fmt.Errorf("share_probability should be within [0, 1], but it is %f", strategy.ShareProbability)
+	}
+	if strategy.IndividualSpread < 0 {
+		return nil, fmt.Errorf("individual_spread should not be negative, but it is %f", strategy.IndividualSpread)
+	}
+	return strategy, nil
+}
+
+func newMixedTrust(params Parameters) (engine.Strategy, error) {
+	perCitizen := params["per_citizen"]
+	if perCitizen != 0 && perCitizen != 1 {
+		return nil, fmt.Errorf("per_citizen should be 0 or 1, but it is %f", perCitizen)
+	}
+	mixed, err := NewMixed(
+		&Reciprocity{Kindness: 2},
+		&DoNotTrust{},
+		params["kind_mirror_probability"],
+		perCitizen == 1,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("kind_mirror_probability: %w", err)
+	}
+	return mixed, nil
+}
+
+func init() {
+	Register(Info{
+		Name:        "probabilistic_sharing",
+		Description: "eats enough to survive and helps each hungry person with an individual probability",
+		Parameters: []Parameter{
+			{Name: "share_probability", Description: "the mean probability to help a hungry person", Default: 0.5},
+			{Name: "individual_spread", Description: "the half-width of the range the probability of a citizen is drawn from around share_probability", Default: 0},
+		},
+		Factory: newProbabilisticSharing,
+	})
+	Register(Info{
+		Name:        "mixed_trust",
+		Description: "plays trust_kind_mirror with a probability and do_not_trust otherwise",
+		Parameters: []Parameter{
+			{Name: "kind_mirror_probability", Description: "the probability to play trust_kind_mirror", Default: 0.5},
+			{Name: "per_citizen", Description: "1 to choose once per citizen, 0 to choose for every food portion", Default: 0},
+		},
+		Factory: newMixedTrust,
+	})
+}
@@ -0,0 +1,116 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+func TestProbabilisticSharingState(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		params          Parameters
+		min, max        float64
+		expectDifferent bool
+	}{
+		{name: "no spread", params: Parameters{"share_probability": 0.3}, min: 0.3, max: 0.3},
+		{name: "spread", params: Parameters{"share_probability": 0.5, "individual_spread": 0.2}, min: 0.3, max: 0.7, expectDifferent: true},
+		{name: "clamped", params: Parameters{"share_probability": 0.9, "individual_spread": 0.5}, min: 0.4, max: 1, expectDifferent: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := New("probabilistic_sharing", tc.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			playground := engine.NewPlayground(engine.DefaultConfig(), 1)
+			playground.AddCitizens(s, 100)
+			probabilities := map[float64]struct{}{}
+			clamped := false
+			for _, citizen := range playground.Citizens {
+				probability := citizen.StrategyState.(*sharingPropensity).shareProbability
+				if probability < tc.min || probability > tc.max {
+					t.Fatalf("probability %f is out of [%f, %f]", probability, tc.min, tc.max)
+				}
+				probabilities[probability] = struct{}{}
+				clamped = clamped || probability == 1
+			}
+			if tc.expectDifferent && len(probabilities) < 50 {
+				t.Errorf("only %d distinct probabilities for 100 citizens", len(probabilities))
+			}
+			if tc.max == 1 && !clamped {
+				t.Errorf("no probability was clamped to 1")
+			}
+		})
+	}
+
+	for _, params := range []Parameters{
+		{"share_probability": -0.1},
+		{"share_probability": 1.1},
+		{"individual_spread": -0.1},
+	} {
+		if _, err := New("probabilistic_sharing", params); err == nil {
+			t.Errorf("expected an error for %v", params)
+		}
+	}
+}
+
+// markedStrategy eats the whole portion with its mark as the comment.
+type markedStrategy struct {
+	mark string
+}
+
+func (strategy *markedStrategy) HandleFood(observation *engine.Observation, food engine.Food) []engine.Action {
+	return []engine.Action{{
+		ActionType:  engine.ActionTypeEat,
+		Amount:      food.Amount,
+		Destination: observation.Self.ID(),
+		Comment:     strategy.mark,
+	}}
+}
+
+func TestMixed(t *testing.T) {
+	a, b := &markedStrategy{mark: "a"}, &markedStrategy{mark: "b"}
+	for _, perCitizen := range []bool{false, true} {
+		mixed, err := NewMixed(a, b, 0.5, perCitizen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		playground := engine.NewPlayground(engine.DefaultConfig(), 1)
+		playground.AddCitizens(mixed, 20)
+
+		playedA, switching := 0, 0
+		for _, citizen := range playground.Citizens {
+			marks := map[string]uint{}
+			for i := 0; i < 20; i++ {
+				marks[mixed.HandleFood(playground.Observe(citizen), engine.Food{Amount: 1000})[0].Comment]++
+			}
+			playedA += int(marks["a"])
+			if len(marks) > 1 {
+				switching++
+			}
+		}
+		if playedA == 0 || playedA == 400 {
+			t.Errorf("per citizen %v: A was played %d times of 400", perCitizen, playedA)
+		}
+		if perCitizen && switching != 0 {
+			t.Errorf("%d citizens changed their choice", switching)
+		}
+		if !perCitizen && switching == 0 {
+			t.Errorf("every citizen played the same strategy for every portion")
+		}
+	}
+
+	stateful, err := New("probabilistic_sharing", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewMixed(a, stateful, 0.5, false); err == nil {
+		t.Errorf("a stateful strategy was mixed")
+	}
+	if _, err := NewMixed(a, b, 1.5, false); err == nil {
+		t.Errorf("expected an error for probability 1.5")
+	}
+	if _, err := New("mixed_trust", Parameters{"per_citizen": 2}); err == nil {
+		t.Errorf("expected an error for per_citizen 2")
+	}
+}
@@ -0,0 +1,93 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// Thief never helps anybody except own children, eats the rest, and
+// attempts to steal from random people who have hidden food.
+type Thief struct {
+	DoNotTrust
+}
+
+var _ engine.Thief = (*Thief)(nil)
+
+func (strategy *Thief) ChooseTheftTargets(observation *engine.Observation) []engine.PersonID {
+	var candidates []engine.PersonID
+	for _, candidate := range observation.People() {
+		if candidate.Citizen().ID() != observation.Self.ID() && candidate.OwnsFood() > 0 {
+			candidates = append(candidates, candidate.ID())
+		}
+	}
+	observation.RandShuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	return candidates
+}
+
+// GuardAndHide eats enough to survive, feeds own children, spends
+// Guard food to guard the hidden food and hides the rest in portions
+// of StashSize.
+type GuardAndHide struct {
+	Guard     uint
+	StashSize uint
+}
+
+func (strategy *GuardAndHide) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.childPreservation()
+	if guard := observation.Guard(); p.amount > strategy.Guard && guard < strategy.Guard {
+		p.add(engine.ActionTypeGuard, strategy.Guard-guard, observation.Self.ID(), "guarding")
+	}
+	for p.amount > 0 {
+		p.add(engine.ActionTypeHide, strategy.StashSize, observation.Self.ID(), "hiding")
+	}
+	return p.actions
+}
+
+func newGuardAndHide(params Parameters) (engine.Strategy, error) {
+	guard := params["guard"]
+	if guard < 0 || guard != float64(uint(guard)) {
+		return nil, fmt.Errorf("guard should be a non-negative integer, but it is %f", guard)
+	}
+	stashSize := params["stash_size"]
+	if stashSize <= 0 || stashSize != float64(uint(stashSize)) {
+		return nil, fmt.Errorf("stash_size should be a positive integer, but it is %f", stashSize)
+	}
+	return &GuardAndHide{
+		Guard:     uint(guard),
+		StashSize: uint(stashSize),
+	}, nil
+}
+
+func requireTheft(cfg *engine.Config) error {
+	if !cfg.EnableTheft {
+		return fmt.Errorf("requires enable_theft")
+	}
+	return nil
+}
+
+func init() {
+	Register(Info{
+		Name:        "thief",
+		Description: "never helps anybody except own children and steals hidden food of random people (theft)",
+		CheckWorld:  requireTheft,
+		Factory:     newWithoutParameters(func() engine.Strategy { return &Thief{} }),
+	})
+	Register(Info{
+		Name:        "guard_and_hide",
+		Description: "eats enough to survive, guards the hidden food and hides the rest in small portions (theft)",
+		Parameters: []Parameter{
+			{Name: "guard", Description: "the food spent on guarding per week", Default: 100},
+			{Name: "stash_size", Description: "the size of a single hidden portion", Default: 100},
+		},
+		CheckWorld: requireTheft,
+		Factory:    newGuardAndHide,
+	})
+}
@@ -0,0 +1,70 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+func TestThief(t *testing.T) {
+	cfg := engine.DefaultConfig()
+	cfg.EnableTheft = true
+	playground := engine.NewPlayground(cfg, 1)
+	thief := &Thief{}
+	playground.AddCitizens(thief, 1)
+	playground.AddCitizens(&GuardAndHide{StashSize: 100}, 3)
+	citizen := playground.Citizens[0]
+	for idx, other := range playground.Citizens {
+		other.OwnsFood = uint(idx%2) * 100
+	}
+	citizen.OwnsFood = 100
+
+	people := map[engine.PersonID]*engine.Person{}
+	for _, person := range playground.People() {
+		people[person.ID()] = person
+	}
+	targets := thief.ChooseTheftTargets(playground.Observe(citizen))
+	if len(targets) != 2 {
+		t.Fatalf("chose %d targets instead of 2", len(targets))
+	}
+	for _, id := range targets {
+		if target := people[id]; target == nil || target.Citizen == citizen || target.OwnsFood == 0 {
+			t.Errorf("chose a target without hidden food or itself: %+v", target)
+		}
+	}
+}
+
+func TestGuardAndHide(t *testing.T) {
+	cfg := engine.DefaultConfig()
+	cfg.EnableTheft = true
+	cfg.EnableChildren = false
+	playground := engine.NewPlayground(cfg, 1)
+	strategy := &GuardAndHide{Guard: 150, StashSize: 100}
+	playground.AddCitizens(strategy, 1)
+	citizen := playground.Citizens[0]
+	citizen.HasEnergy = cfg.RequiredEnergy - 200
+
+	var eaten, guard, hidden uint
+	for _, action := range strategy.HandleFood(playground.Observe(citizen), engine.Food{Amount: 600}) {
+		switch action.ActionType {
+		case engine.ActionTypeEat:
+			eaten += action.Amount
+		case engine.ActionTypeGuard:
+			guard += action.Amount
+		case engine.ActionTypeHide:
+			if action.Amount > strategy.StashSize {
+				t.Errorf("hid a stash of %d", action.Amount)
+			}
+			hidden += action.Amount
+		}
+	}
+	if eaten != 200 || guard != 150 || hidden != 250 {
+		t.Errorf("ate %d, guarded %d, hid %d; expected 200, 150 and 250", eaten, guard, hidden)
+	}
+
+	for _, params := range []Parameters{{"guard": -1}, {"stash_size": 0}, {"stash_size": 1.5}} {
+		if _, err := New("guard_and_hide", params); err == nil {
+			t.Errorf("expected an error for %v", params)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package strategy
+
+import (
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+// DoNotTrust never helps anybody except own children.
+type DoNotTrust struct{}
+
+func (strategy *DoNotTrust) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.childPreservation()
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+// TrustOnlyOnce helps everybody who was never spotted as greedy.
+type TrustOnlyOnce struct{}
+
+func (strategy *TrustOnlyOnce) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.childPreservation()
+	p.altruism(observation.HungryPeople(), func(candidate engine.PersonView) bool {
+		return !candidate.Citizen().SpottedAsGreedyOnce()
+	})
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+// TrustEveryGoodTime helps everybody who was not greedy the last time.
+type TrustEveryGoodTime struct{}
+
+func (strategy *TrustEveryGoodTime) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.childPreservation()
+	p.altruism(observation.HungryPeople(), func(candidate engine.PersonView) bool {
+		return !candidate.Citizen().SpottedAsGreedyLastTime()
+	})
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+// TrustAlways helps everybody.
+type TrustAlways struct{}
+
+func (strategy *TrustAlways) HandleFood(
+	observation *engine.Observation,
+	food engine.Food,
+) []engine.Action {
+	p := newPlan(observation, food)
+	p.selfPreservation()
+	p.childPreservation()
+	p.altruism(observation.HungryPeople(), nil)
+	return p.rest(engine.ActionTypeEat, "reserving")
+}
+
+func init() {
+	Register(Info{
+		Name:        "do_not_trust",
+		Description: "never helps anybody except own children",
+		Factory:     newWithoutParameters(func() engine.Strategy { return &DoNotTrust{} }),
+	})
+	Register(Info{
+		Name:        "trust_only_once",
+		Description: "helps the hungry who were never spotted as greedy",
+		Factory:     newWithoutParameters(func() engine.Strategy { return &TrustOnlyOnce{} }),
+	})
+	Register(Info{
+		Name:        "trust_every_good_time",
+		Description: "helps the hungry who were not greedy the last time",
+		Factory:     newWithoutParameters(func() engine.Strategy { return &TrustEveryGoodTime{} }),
+	})
+	Register(Info{
+		Name:        "trust_always",
+		Description: "helps all the hungry",
+		Factory:     newWithoutParameters(func() engine.Strategy { return &TrustAlways{} }),
+	})
+}
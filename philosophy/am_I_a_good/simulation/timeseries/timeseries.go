@@ -0,0 +1,252 @@
+// Package timeseries records the state of playgrounds week by week.
+package timeseries
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+)
+
+type Format uint
+
+const (
+	FormatUndefined = Format(iota)
+	FormatCSV
+	FormatJSONL
+)
+
+func (format Format) String() string {
+	switch format {
+	case FormatUndefined:
+		return "undefined"
+	case FormatCSV:
+		return "csv"
+	case FormatJSONL:
+		return "jsonl"
+	}
+	return "unknown"
+}
+
+// ParseFormat parses a format name ("csv" or "jsonl").
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "csv":
+		return FormatCSV, nil
+	case "jsonl", "ndjson":
+		return FormatJSONL, nil
+	}
+	return FormatUndefined, fmt.Errorf("unknown time series format '%s', expected 'csv' or 'jsonl'", s)
+}
+
+// FormatFromPath guesses the format by the file extension.
+func FormatFromPath(path string) (Format, error) {
+	return ParseFormat(strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// Row is the state of a playground after a week.
+type Row struct {
+	Try                  uint            `json:"try"`
+	Week                 uint            `json:"week"`
+	Population           uint            `json:"population"`
+	PopulationByStrategy map[string]uint `json:"population_by_strategy"`
+	Children             uint            `json:"children"`
+	engine.WeekStats
+}
+
+// weekStatsColumns are the CSV columns of engine.WeekStats: the JSON
+// names of its fields, in the order of the fields.
+var weekStatsColumns = func() []string {
+	t := reflect.TypeOf(engine.WeekStats{})
+	result := make([]string, 0, t.NumField())
+	for fieldIdx := 0; fieldIdx < t.NumField(); fieldIdx++ {
+		name, _, _ := strings.Cut(t.Field(fieldIdx).Tag.Get("json"), ",")
+		result = append(result, name)
+	}
+	return result
+}()
+
+// Recorder writes the rows of every try to a temporary file of the try
+// and appends the file to the output when the try finishes, so that
+// rows of different tries are never interleaved in the output and
+// the rows are not kept in memory.
+//
+// It is safe for concurrent use, but the rows of a single try should be
+// recorded sequentially.
+type Recorder struct {
+	writer        io.Writer
+	format        Format
+	strategyNames []string
+
+	locker        sync.Mutex
+	tries         map[uint]*tryWriter
+	headerWritten bool
+	err           error
+}
+
+// tryWriter writes the rows of a single try to its temporary file.
+type tryWriter struct {
+	file     *os.File
+	buffered *bufio.Writer
+	csv      *csv.Writer
+	json     *json.Encoder
+	err      error
+}
+
+// NewRecorder returns a recorder which writes rows in the given format.
+// strategyNames define the columns of the per-strategy population.
+func NewRecorder(w io.Writer, format Format, strategyNames []string) (*Recorder, error) {
+	switch format {
+	case FormatCSV, FormatJSONL:
+	default:
+		return nil, fmt.Errorf("unsupported format: %v", format)
+	}
+	return &Recorder{
+		writer:        w,
+		format:        format,
+		strategyNames: strategyNames,
+		tries:         map[uint]*tryWriter{},
+	}, nil
+}
+
+// Record writes the current state of the playground.
+// populationByStrategy is in the order of the strategyNames.
+func (recorder *Recorder) Record(tryIdx uint, week uint, playground *engine.Playground, populationByStrategy []uint) {
+	row := Row{
+		Try:                  tryIdx,
+		Week:                 week,
+		Population:           uint(len(playground.Citizens)),
+		PopulationByStrategy: make(map[string]uint, len(recorder.strategyNames)),
+		Children:             playground.Children(),
+		WeekStats:            playground.LastWeekStats(),
+	}
+	for idx, name := range recorder.strategyNames {
+		row.PopulationByStrategy[name] = populationByStrategy[idx]
+	}
+
+	try := recorder.tryWriter(tryIdx)
+	if try.err != nil {
+		return
+	}
+	switch recorder.format {
+	case FormatCSV:
+		try.err = try.csv.Write(recorder.csvRecord(row))
+	case FormatJSONL:
+		try.err = try.json.Encode(row)
+	}
+	if try.err != nil {
+		try.err = fmt.Errorf("unable to write a row: %w", try.err)
+	}
+}
+
+func (recorder *Recorder) tryWriter(tryIdx uint) *tryWriter {
+	recorder.locker.Lock()
+	defer recorder.locker.Unlock()
+	if try, ok := recorder.tries[tryIdx]; ok {
+		return try
+	}
+	try := &tryWriter{}
+	try.file, try.err = os.CreateTemp("", "timeseries-*")
+	if try.err == nil {
+		try.buffered = bufio.NewWriter(try.file)
+		try.csv = csv.NewWriter(try.buffered)
+		try.json = json.NewEncoder(try.buffered)
+	} else {
+		try.err = fmt.Errorf("unable to create a temporary file: %w", try.err)
+	}
+	recorder.tries[tryIdx] = try
+	return try
+}
+
+// FinishTry appends all the rows of the try to the output.
+func (recorder *Recorder) FinishTry(tryIdx uint) error {
+	try := recorder.tryWriter(tryIdx)
+	if try.file != nil {
+		defer os.Remove(try.file.Name())
+		defer try.file.Close()
+	}
+	if try.err == nil {
+		try.csv.Flush()
+		try.err = try.csv.Error()
+	}
+	if try.err == nil {
+		try.err = try.buffered.Flush()
+	}
+	if try.err == nil {
+		_, try.err = try.file.Seek(0, io.SeekStart)
+	}
+
+	recorder.locker.Lock()
+	defer recorder.locker.Unlock()
+	delete(recorder.tries, tryIdx)
+	if recorder.err != nil {
+		return recorder.err
+	}
+	if try.err != nil {
+		recorder.err = try.err
+		return recorder.err
+	}
+	if recorder.format == FormatCSV && !recorder.headerWritten {
+		recorder.err = recorder.writeHeader()
+		if recorder.err != nil {
+			return recorder.err
+		}
+		recorder.headerWritten = true
+	}
+	if _, err := io.Copy(recorder.writer, try.file); err != nil {
+		recorder.err = fmt.Errorf("unable to write the rows of try #%d: %w", tryIdx, err)
+	}
+	return recorder.err
+}
+
+// Err returns the first write error if any.
+func (recorder *Recorder) Err() error {
+	recorder.locker.Lock()
+	defer recorder.locker.Unlock()
+	return recorder.err
+}
+
+func (recorder *Recorder) writeHeader() error {
+	header := []string{"try", "week", "population"}
+	for _, name := range recorder.strategyNames {
+		header = append(header, "population_"+name)
+	}
+	header = append(header, "children")
+	header = append(header, weekStatsColumns...)
+
+	w := csv.NewWriter(recorder.writer)
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("unable to write the header: %w", err)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("unable to write the header: %w", err)
+	}
+	return nil
+}
+
+func (recorder *Recorder) csvRecord(row Row) []string {
+	record := []string{fmtUint(row.Try), fmtUint(row.Week), fmtUint(row.Population)}
+	for _, name := range recorder.strategyNames {
+		record = append(record, fmtUint(row.PopulationByStrategy[name]))
+	}
+	record = append(record, fmtUint(row.Children))
+	weekStats := reflect.ValueOf(row.WeekStats)
+	for fieldIdx := 0; fieldIdx < weekStats.NumField(); fieldIdx++ {
+		record = append(record, strconv.FormatUint(weekStats.Field(fieldIdx).Uint(), 10))
+	}
+	return record
+}
+
+func fmtUint(v uint) string {
+	return strconv.FormatUint(uint64(v), 10)
+}
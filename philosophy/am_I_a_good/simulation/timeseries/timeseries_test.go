@@ -0,0 +1,140 @@
+package timeseries
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/strategy"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// record runs a tiny seeded world with births, graduations and deaths
+// and records every week of it.
+func record(t *testing.T, format Format) []byte {
+	cfg := engine.DefaultConfig()
+	cfg.AmountOfPortions = 10
+	cfg.PersonGraduationInWeeks = 3
+	cfg.PersonExpirationInWeeks = 30
+	cfg.StartBabyEnergy = 5000
+	cfg.CreateBabyEnergy = 6000
+
+	strategyNames := []string{"eat_the_rest", "share_the_rest"}
+	strategies := make([]engine.Strategy, len(strategyNames))
+	for idx, name := range strategyNames {
+		s, err := strategy.New(name, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		strategies[idx] = s
+	}
+
+	var output bytes.Buffer
+	recorder, err := NewRecorder(&output, format, strategyNames)
+	if err != nil {
+		t.Fatal(err)
+	}
+	experiment := &engine.Experiment{
+		Config:   cfg,
+		Tries:    2,
+		Weeks:    12,
+		Seed:     1,
+		Parallel: 1,
+		Populate: func(playground *engine.Playground) {
+			for _, s := range strategies {
+				playground.AddCitizens(s, 5)
+			}
+		},
+		OnWeek: func(tryIdx uint, week uint, playground *engine.Playground) {
+			population := make([]uint, len(strategies))
+			for _, citizen := range playground.Citizens {
+				for idx, s := range strategies {
+					if citizen.Strategy == s {
+						population[idx]++
+					}
+				}
+			}
+			recorder.Record(tryIdx, week, playground, population)
+		},
+		OnFinish: func(tryIdx uint, playground *engine.Playground) {
+			if err := recorder.FinishTry(tryIdx); err != nil {
+				t.Error(err)
+			}
+		},
+	}
+	experiment.Run()
+	if err := recorder.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return output.Bytes()
+}
+
+func TestRecorderGolden(t *testing.T) {
+	for _, format := range []Format{FormatCSV, FormatJSONL} {
+		t.Run(format.String(), func(t *testing.T) {
+			output := record(t, format)
+			path := filepath.Join("testdata", "tiny."+format.String())
+			if *update {
+				if err := os.WriteFile(path, output, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bytes.Equal(output, expected) {
+				return
+			}
+			outputLines, expectedLines := strings.Split(string(output), "\n"), strings.Split(string(expected), "\n")
+			for lineIdx := 0; lineIdx < len(outputLines) && lineIdx < len(expectedLines); lineIdx++ {
+				if outputLines[lineIdx] != expectedLines[lineIdx] {
+					t.Errorf("line %d differs from %s (run with -update if the change is intended):\n%s\nexpected:\n%s",
+						lineIdx+1, path, outputLines[lineIdx], expectedLines[lineIdx])
+					return
+				}
+			}
+			t.Errorf("%d lines instead of %d lines of %s (run with -update if the change is intended)",
+				len(outputLines), len(expectedLines), path)
+		})
+	}
+}
+
+func TestRecorderTriesAreNotInterleaved(t *testing.T) {
+	playground := engine.NewPlayground(engine.DefaultConfig(), 1)
+	var output bytes.Buffer
+	recorder, err := NewRecorder(&output, FormatCSV, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for week := uint(0); week < 3; week++ {
+		for tryIdx := uint(0); tryIdx < 2; tryIdx++ {
+			recorder.Record(tryIdx, week, playground, nil)
+		}
+	}
+	for _, tryIdx := range []uint{1, 0} {
+		if err := recorder.FinishTry(tryIdx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var tries []string
+	for idx, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		if idx == 0 {
+			if !strings.HasPrefix(line, "try,week,population,children,births,") {
+				t.Errorf("unexpected header: %s", line)
+			}
+			continue
+		}
+		try, _, _ := strings.Cut(line, ",")
+		tries = append(tries, try)
+	}
+	if strings.Join(tries, "") != "111000" {
+		t.Errorf("the rows of the tries are interleaved: %v", tries)
+	}
+}
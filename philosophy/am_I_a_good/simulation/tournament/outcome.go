@@ -0,0 +1,228 @@
+package tournament
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/scenario"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/stats"
+)
+
+// Outcome is the result of a tournament.
+type Outcome struct {
+	Tournament *Tournament
+	Matches    []Match
+
+	// CrossMatchComparisons compares the strategies of different
+	// matches in ModeMonoculture, where a match has no comparisons
+	// of its own: all the matches share the master seed, so the growth
+	// rates are paired by the try index.
+	CrossMatchComparisons []scenario.Comparison
+}
+
+// crossMatchComparisons compares the only strategies of the matches
+// between each other (see Outcome.CrossMatchComparisons).
+func (outcome *Outcome) crossMatchComparisons() []scenario.Comparison {
+	if len(outcome.Matches) < 2 || len(outcome.Matches[0].Result.Tries) < 2 {
+		return nil
+	}
+	names := make([]string, len(outcome.Matches))
+	growthRates := make([][]float64, len(outcome.Matches))
+	for matchIdx, match := range outcome.Matches {
+		if len(match.Strategies) != 1 {
+			panic(fmt.Sprintf("match %v is not a monoculture", match.Strategies))
+		}
+		names[matchIdx] = match.Strategies[0]
+		growthRates[matchIdx] = match.Result.GrowthRates(0)
+	}
+	rng := rand.New(rand.NewSource(outcome.Matches[0].Result.Seed))
+	return scenario.Compare(names, growthRates, rng)
+}
+
+// comparisons returns the comparisons the standings are based on.
+func (outcome *Outcome) comparisons() []scenario.Comparison {
+	if outcome.Tournament.Mode == ModeMonoculture {
+		return outcome.CrossMatchComparisons
+	}
+	var result []scenario.Comparison
+	for _, match := range outcome.Matches {
+		result = append(result, match.Statistics.Comparisons...)
+	}
+	return result
+}
+
+// Standing is the summary of a strategy across all its matches.
+type Standing struct {
+	Strategy string
+	Matches  uint
+	Wins     uint
+	Losses   uint
+	Ties     uint
+
+	// MeanGrowthRate is the average of the mean growth rates (in percents)
+	// across the matches.
+	MeanGrowthRate float64
+}
+
+// GrowthMatrix returns the mean growth rate (in percents) of the
+// strategy "row" in the match where it played against the strategy
+// "column" (in ModeMonoculture "column" equals "row"). Missing cells
+// are NaN.
+func (outcome *Outcome) GrowthMatrix() ([]string, [][]float64) {
+	participants := outcome.Tournament.Participants()
+	index := map[string]int{}
+	for idx, name := range participants {
+		index[name] = idx
+	}
+	matrix := make([][]float64, len(participants))
+	for row := range matrix {
+		matrix[row] = make([]float64, len(participants))
+		for column := range matrix[row] {
+			matrix[row][column] = math.NaN()
+		}
+	}
+	for _, match := range outcome.Matches {
+		switch len(match.Strategies) {
+		case 1:
+			idx := index[match.Strategies[0]]
+			matrix[idx][idx] = match.Statistics.Strategies[0].Mean
+		case 2:
+			idxA, idxB := index[match.Strategies[0]], index[match.Strategies[1]]
+			matrix[idxA][idxB] = match.Statistics.Strategies[0].Mean
+			matrix[idxB][idxA] = match.Statistics.Strategies[1].Mean
+		}
+	}
+	return participants, matrix
+}
+
+// Standings returns the wins, losses and ties of each strategy, the best first.
+//
+// A strategy wins a comparison if its mean growth rate is higher and the
+// difference is statistically significant; otherwise it is a tie.
+// In ModeMonoculture the strategies are compared across the matches
+// (see Outcome.CrossMatchComparisons).
+func (outcome *Outcome) Standings() []Standing {
+	byName := map[string]*Standing{}
+	var result []*Standing
+	get := func(name string) *Standing {
+		if standing, ok := byName[name]; ok {
+			return standing
+		}
+		standing := &Standing{Strategy: name}
+		byName[name] = standing
+		result = append(result, standing)
+		return standing
+	}
+
+	for _, match := range outcome.Matches {
+		for strategyIdx, name := range match.Strategies {
+			standing := get(name)
+			standing.Matches++
+			standing.MeanGrowthRate += match.Statistics.Strategies[strategyIdx].Mean
+		}
+	}
+	for _, comparison := range outcome.comparisons() {
+		a, b := get(comparison.StrategyA), get(comparison.StrategyB)
+		switch {
+		case !comparison.IsSignificant(stats.DefaultAlpha):
+			a.Ties++
+			b.Ties++
+		case comparison.MeanDifference > 0:
+			a.Wins++
+			b.Losses++
+		default:
+			a.Losses++
+			b.Wins++
+		}
+	}
+
+	standings := make([]Standing, 0, len(result))
+	for _, standing := range result {
+		standing.MeanGrowthRate /= float64(standing.Matches)
+		standings = append(standings, *standing)
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Wins != standings[j].Wins {
+			return standings[i].Wins > standings[j].Wins
+		}
+		if standings[i].Losses != standings[j].Losses {
+			return standings[i].Losses < standings[j].Losses
+		}
+		return standings[i].MeanGrowthRate > standings[j].MeanGrowthRate
+	})
+	return standings
+}
+
+// Print writes the results of every match, the growth matrix and
+// the standings.
+func (outcome *Outcome) Print(w io.Writer) {
+	fmt.Fprintf(w, "tournament: %s\n", outcome.Tournament.Mode)
+	if len(outcome.Matches) > 0 {
+		fmt.Fprintf(w, "master seed: %d\n", outcome.Matches[0].Result.Seed)
+	}
+
+	for _, match := range outcome.Matches {
+		fmt.Fprintf(w, "match %v:\n", match.Strategies)
+		for strategyIdx, summary := range match.Statistics.Strategies {
+			fmt.Fprintf(w, "\t%s: growth rate %.2f%% (%.0f%% CI [%.2f%%, %.2f%%])\n",
+				match.Strategies[strategyIdx], summary.Mean,
+				stats.DefaultConfidence*100, summary.CILow, summary.CIHigh)
+		}
+		printComparisons(w, match.Statistics.Comparisons)
+	}
+	if len(outcome.CrossMatchComparisons) > 0 {
+		fmt.Fprintf(w, "across the matches (tries paired by index, the matches share the master seed):\n")
+		printComparisons(w, outcome.CrossMatchComparisons)
+	}
+
+	if outcome.Tournament.Mode != ModeAllInOne {
+		names, matrix := outcome.GrowthMatrix()
+		fmt.Fprintf(w, "\ngrowth rate matrix (of the row strategy against the column strategy):\n")
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintf(tw, "\t")
+		for _, name := range names {
+			fmt.Fprintf(tw, "%s\t", name)
+		}
+		fmt.Fprintf(tw, "\n")
+		for row, name := range names {
+			fmt.Fprintf(tw, "%s\t", name)
+			for column := range names {
+				if math.IsNaN(matrix[row][column]) {
+					fmt.Fprintf(tw, "-\t")
+					continue
+				}
+				fmt.Fprintf(tw, "%.2f%%\t", matrix[row][column])
+			}
+			fmt.Fprintf(tw, "\n")
+		}
+		tw.Flush()
+	}
+
+	fmt.Fprintf(w, "\nstandings:\n")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "strategy\tmatches\twins\tlosses\tties\tmean growth rate\t\n")
+	for _, standing := range outcome.Standings() {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%.2f%%\t\n",
+			standing.Strategy, standing.Matches, standing.Wins, standing.Losses, standing.Ties, standing.MeanGrowthRate)
+	}
+	tw.Flush()
+}
+
+func printComparisons(w io.Writer, comparisons []scenario.Comparison) {
+	for _, comparison := range comparisons {
+		verdict := "tie"
+		switch {
+		case !comparison.IsSignificant(stats.DefaultAlpha):
+		case comparison.MeanDifference > 0:
+			verdict = comparison.StrategyA + " wins"
+		default:
+			verdict = comparison.StrategyB + " wins"
+		}
+		fmt.Fprintf(w, "\t%s vs %s: %s (p-value %.4f, Holm-Bonferroni adjusted %.4f)\n",
+			comparison.StrategyA, comparison.StrategyB, verdict, comparison.PValue, comparison.AdjustedPValue)
+	}
+}
@@ -0,0 +1,182 @@
+// Package tournament pits strategies against each other in series of
+// scenarios sharing the same world.
+package tournament
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/scenario"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/strategy"
+)
+
+type Mode uint
+
+const (
+	ModeUndefined = Mode(iota)
+
+	// ModeMonoculture runs every strategy alone.
+	ModeMonoculture
+
+	// ModeOneVsBaseline runs every strategy together with the baseline one.
+	ModeOneVsBaseline
+
+	// ModeRoundRobin runs every pair of strategies.
+	ModeRoundRobin
+
+	// ModeAllInOne runs all the strategies in a single pool.
+	ModeAllInOne
+)
+
+func (mode Mode) String() string {
+	switch mode {
+	case ModeUndefined:
+		return "undefined"
+	case ModeMonoculture:
+		return "monoculture"
+	case ModeOneVsBaseline:
+		return "one-vs-baseline"
+	case ModeRoundRobin:
+		return "round-robin"
+	case ModeAllInOne:
+		return "all-in-one"
+	}
+	return "unknown"
+}
+
+// ParseMode parses the output of Mode.String.
+func ParseMode(s string) (Mode, error) {
+	for mode := ModeMonoculture; mode <= ModeAllInOne; mode++ {
+		if strings.EqualFold(s, mode.String()) {
+			return mode, nil
+		}
+	}
+	return ModeUndefined, fmt.Errorf("unknown tournament mode '%s', expected one of: %s, %s, %s, %s",
+		s, ModeMonoculture, ModeOneVsBaseline, ModeRoundRobin, ModeAllInOne)
+}
+
+// Tournament is a series of scenarios which differ only by the population.
+type Tournament struct {
+	// Base defines everything except the population.
+	Base *scenario.Scenario
+
+	Mode Mode
+
+	// Strategies are the participants; all known strategies (which
+	// are allowed in the world of Base) if empty.
+	Strategies []string
+
+	// Baseline is the strategy every other one plays against
+	// in ModeOneVsBaseline.
+	Baseline string
+
+	// Citizens is the initial amount of citizens of each strategy.
+	Citizens uint
+}
+
+// Participants returns the names of the participating strategies.
+func (tournament *Tournament) Participants() []string {
+	if len(tournament.Strategies) > 0 {
+		return tournament.Strategies
+	}
+	var result []string
+	for _, info := range strategy.All() {
+		if info.Supports(&tournament.Base.World) != nil {
+			continue
+		}
+		result = append(result, info.Name)
+	}
+	return result
+}
+
+// Lineups returns the strategies of every match of the tournament.
+func (tournament *Tournament) Lineups() ([][]string, error) {
+	participants := tournament.Participants()
+	for _, name := range participants {
+		if _, err := strategy.Get(name); err != nil {
+			return nil, err
+		}
+	}
+
+	var result [][]string
+	switch tournament.Mode {
+	case ModeMonoculture:
+		for _, name := range participants {
+			result = append(result, []string{name})
+		}
+	case ModeOneVsBaseline:
+		if _, err := strategy.Get(tournament.Baseline); err != nil {
+			return nil, fmt.Errorf("invalid baseline: %w", err)
+		}
+		for _, name := range participants {
+			if name == tournament.Baseline {
+				continue
+			}
+			result = append(result, []string{tournament.Baseline, name})
+		}
+	case ModeRoundRobin:
+		for idxA := range participants {
+			for idxB := idxA + 1; idxB < len(participants); idxB++ {
+				result = append(result, []string{participants[idxA], participants[idxB]})
+			}
+		}
+	case ModeAllInOne:
+		result = append(result, participants)
+	default:
+		return nil, fmt.Errorf("unsupported tournament mode: %v", tournament.Mode)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no matches to play")
+	}
+	return result, nil
+}
+
+// Match is a single scenario of the tournament.
+type Match struct {
+	Strategies []string
+	Result     *scenario.Result
+	Statistics *scenario.Statistics
+}
+
+// Run plays all the matches one by one (the tries of each match are run
+// in parallel). Every match uses the same master seed, so the tries
+// of different matches are comparable by the index.
+func (tournament *Tournament) Run(opts scenario.RunOptions) (*Outcome, error) {
+	if tournament.Citizens == 0 {
+		return nil, fmt.Errorf("the amount of citizens per strategy should be positive")
+	}
+	lineups, err := tournament.Lineups()
+	if err != nil {
+		return nil, err
+	}
+
+	outcome := &Outcome{
+		Tournament: tournament,
+	}
+	for _, lineup := range lineups {
+		matchScenario := *tournament.Base
+		matchScenario.Population = nil
+		for _, name := range lineup {
+			matchScenario.Population = append(matchScenario.Population, scenario.Population{
+				Strategy: name,
+				Citizens: tournament.Citizens,
+			})
+		}
+		if err := matchScenario.Validate(); err != nil {
+			return nil, fmt.Errorf("match %v: %w", lineup, err)
+		}
+		result, err := matchScenario.Run(opts)
+		if err != nil {
+			return nil, fmt.Errorf("match %v: %w", lineup, err)
+		}
+		outcome.Matches = append(outcome.Matches, Match{
+			Strategies: lineup,
+			Result:     result,
+			Statistics: result.Statistics(),
+		})
+	}
+	if tournament.Mode == ModeMonoculture {
+		outcome.CrossMatchComparisons = outcome.crossMatchComparisons()
+	}
+	return outcome, nil
+}
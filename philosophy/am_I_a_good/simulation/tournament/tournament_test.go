@@ -0,0 +1,217 @@
+package tournament
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/scenario"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/stats"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/strategy"
+)
+
+func baseScenario(t *testing.T) *scenario.Scenario {
+	s, err := scenario.Parse([]byte(`
+name: base
+tries: 4
+weeks: 20
+population:
+  - strategy: eat_the_rest
+    citizens: 10
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestParseMode(t *testing.T) {
+	for mode := ModeMonoculture; mode <= ModeAllInOne; mode++ {
+		parsed, err := ParseMode(mode.String())
+		if err != nil || parsed != mode {
+			t.Errorf("'%s' is parsed as %v (%v)", mode, parsed, err)
+		}
+	}
+	if mode, err := ParseMode("Round-Robin"); err != nil || mode != ModeRoundRobin {
+		t.Errorf("the mode should be case-insensitive: %v (%v)", mode, err)
+	}
+	if _, err := ParseMode("knockout"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
+
+func TestLineups(t *testing.T) {
+	participants := []string{"do_not_trust", "eat_the_rest", "trust_kind_mirror"}
+	for _, tc := range []struct {
+		name       string
+		mode       Mode
+		strategies []string
+		baseline   string
+		lineups    [][]string
+		error      bool
+	}{
+		{
+			name:    "monoculture",
+			mode:    ModeMonoculture,
+			lineups: [][]string{{"do_not_trust"}, {"eat_the_rest"}, {"trust_kind_mirror"}},
+		},
+		{
+			name:     "one vs baseline",
+			mode:     ModeOneVsBaseline,
+			baseline: "eat_the_rest",
+			lineups:  [][]string{{"eat_the_rest", "do_not_trust"}, {"eat_the_rest", "trust_kind_mirror"}},
+		},
+		{
+			name: "round robin",
+			mode: ModeRoundRobin,
+			lineups: [][]string{
+				{"do_not_trust", "eat_the_rest"},
+				{"do_not_trust", "trust_kind_mirror"},
+				{"eat_the_rest", "trust_kind_mirror"},
+			},
+		},
+		{
+			name:    "all in one",
+			mode:    ModeAllInOne,
+			lineups: [][]string{participants},
+		},
+		{name: "unknown strategy", mode: ModeMonoculture, strategies: []string{"eat_everybody"}, error: true},
+		{name: "invalid baseline", mode: ModeOneVsBaseline, baseline: "eat_everybody", error: true},
+		{name: "only the baseline", mode: ModeOneVsBaseline, strategies: []string{"eat_the_rest"}, baseline: "eat_the_rest", error: true},
+		{name: "undefined mode", mode: ModeUndefined, error: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tournament := &Tournament{
+				Base:       baseScenario(t),
+				Mode:       tc.mode,
+				Strategies: tc.strategies,
+				Baseline:   tc.baseline,
+			}
+			if tournament.Strategies == nil {
+				tournament.Strategies = participants
+			}
+			lineups, err := tournament.Lineups()
+			if tc.error {
+				if err == nil {
+					t.Errorf("expected an error, got %v", lineups)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(lineups, tc.lineups) {
+				t.Errorf("lineups %v, expected %v", lineups, tc.lineups)
+			}
+		})
+	}
+}
+
+func TestParticipants(t *testing.T) {
+	tournament := &Tournament{Base: baseScenario(t)}
+	participates := func(name string) bool {
+		for _, participant := range tournament.Participants() {
+			if participant == name {
+				return true
+			}
+		}
+		return false
+	}
+	for _, name := range tournament.Participants() {
+		info, err := strategy.Get(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := info.Supports(&tournament.Base.World); err != nil {
+			t.Errorf("strategy '%s' participates in a world which does not support it: %v", name, err)
+		}
+	}
+	if !participates("eat_the_rest") || participates("share_everything") {
+		t.Errorf("unexpected participants: %v", tournament.Participants())
+	}
+
+	tournament.Base.World.AllowSuicidalStrategies = true
+	if !participates("share_everything") {
+		t.Errorf("a suicidal strategy does not participate in a world which allows it: %v", tournament.Participants())
+	}
+
+	tournament.Strategies = []string{"share_everything", "grudger"}
+	if participants := tournament.Participants(); !reflect.DeepEqual(participants, tournament.Strategies) {
+		t.Errorf("the explicit participants are %v instead of %v", participants, tournament.Strategies)
+	}
+}
+
+func TestStandings(t *testing.T) {
+	match := func(names []string, means []float64, comparisons ...scenario.Comparison) Match {
+		statistics := &scenario.Statistics{Comparisons: comparisons}
+		for _, mean := range means {
+			statistics.Strategies = append(statistics.Strategies, stats.Summary{Mean: mean})
+		}
+		return Match{Strategies: names, Statistics: statistics}
+	}
+	outcome := &Outcome{
+		Tournament: &Tournament{Mode: ModeRoundRobin},
+		Matches: []Match{
+			match([]string{"a", "b"}, []float64{10, -10},
+				scenario.Comparison{StrategyA: "a", StrategyB: "b", MeanDifference: 20, AdjustedPValue: 0.001}),
+			match([]string{"a", "c"}, []float64{0, 2},
+				scenario.Comparison{StrategyA: "a", StrategyB: "c", MeanDifference: -2, AdjustedPValue: 0.5}),
+			match([]string{"b", "c"}, []float64{-20, 20},
+				scenario.Comparison{StrategyA: "b", StrategyB: "c", MeanDifference: -40, AdjustedPValue: 0.01}),
+		},
+	}
+
+	expected := []Standing{
+		{Strategy: "c", Matches: 2, Wins: 1, Ties: 1, MeanGrowthRate: 11},
+		{Strategy: "a", Matches: 2, Wins: 1, Ties: 1, MeanGrowthRate: 5},
+		{Strategy: "b", Matches: 2, Losses: 2, MeanGrowthRate: -15},
+	}
+	if standings := outcome.Standings(); !reflect.DeepEqual(standings, expected) {
+		t.Errorf("standings %+v, expected %+v", standings, expected)
+	}
+}
+
+func TestRun(t *testing.T) {
+	tournament := &Tournament{
+		Base:       baseScenario(t),
+		Mode:       ModeMonoculture,
+		Strategies: []string{"do_not_trust", "eat_the_rest"},
+		Citizens:   10,
+	}
+	outcome, err := tournament.Run(scenario.RunOptions{Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcome.Matches) != 2 {
+		t.Fatalf("%d matches instead of 2", len(outcome.Matches))
+	}
+	for _, match := range outcome.Matches {
+		if len(match.Result.Tries) != int(tournament.Base.Tries) || match.Result.Seed != 1 {
+			t.Errorf("match %v is not run with the tries of the base and the master seed: %+v", match.Strategies, match.Result)
+		}
+		if match.Result.Strategies[0].InitialCitizens != tournament.Citizens {
+			t.Errorf("match %v has %d citizens instead of %d",
+				match.Strategies, match.Result.Strategies[0].InitialCitizens, tournament.Citizens)
+		}
+	}
+	if len(outcome.CrossMatchComparisons) != 1 {
+		t.Errorf("%d comparisons across the matches instead of 1", len(outcome.CrossMatchComparisons))
+	}
+
+	names, matrix := outcome.GrowthMatrix()
+	if !reflect.DeepEqual(names, tournament.Strategies) {
+		t.Errorf("the matrix of %v instead of %v", names, tournament.Strategies)
+	}
+	for row := range matrix {
+		for column := range matrix[row] {
+			if math.IsNaN(matrix[row][column]) != (row != column) {
+				t.Errorf("unexpected cell [%d][%d] of a monoculture: %f", row, column, matrix[row][column])
+			}
+		}
+	}
+
+	tournament.Citizens = 0
+	if _, err := tournament.Run(scenario.RunOptions{}); err == nil {
+		t.Error("expected an error for no citizens")
+	}
+}
@@ -0,0 +1,108 @@
+// Package training trains learning strategies (see strategy.QLearner)
+// by playing the scenario over and over again.
+package training
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/engine"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/scenario"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/stats"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/strategy"
+)
+
+// Trainer plays Episodes single tries of the scenario one after another,
+// so that the Learner continues learning from where the previous
+// episode stopped.
+type Trainer struct {
+	Base *scenario.Scenario
+
+	// Target is the index of the population played by the Learner.
+	Target uint
+
+	Learner  *strategy.QLearner
+	Episodes uint
+
+	// Seed is the master seed; episode #i plays the world of
+	// engine.TrySeed(Seed, i).
+	Seed int64
+}
+
+// Episode is the outcome of a single episode.
+type Episode struct {
+	Index uint
+
+	// GrowthRate is the growth rate (in percents) of the Target
+	// population.
+	GrowthRate float64
+}
+
+// Outcome is the result of a training.
+type Outcome struct {
+	Trainer  *Trainer
+	Episodes []Episode
+}
+
+// Run trains the Learner. The Learner is left in the training mode.
+func (trainer *Trainer) Run() (*Outcome, error) {
+	if trainer.Target >= uint(len(trainer.Base.Population)) {
+		return nil, fmt.Errorf("target population #%d does not exist: there are only %d populations",
+			trainer.Target, len(trainer.Base.Population))
+	}
+	strategies := make([]engine.Strategy, trainer.Target+1)
+	strategies[trainer.Target] = trainer.Learner
+	trainer.Learner.Training = true
+
+	outcome := &Outcome{
+		Trainer: trainer,
+	}
+	for episodeIdx := uint(0); episodeIdx < trainer.Episodes; episodeIdx++ {
+		result, err := trainer.Base.Run(scenario.RunOptions{
+			Seed:       engine.TrySeed(trainer.Seed, episodeIdx),
+			OnlyTries:  []uint{0},
+			Strategies: strategies,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("episode #%d: %w", episodeIdx, err)
+		}
+		trainer.Learner.Commit()
+		outcome.Episodes = append(outcome.Episodes, Episode{
+			Index:      episodeIdx,
+			GrowthRate: result.GrowthRates(int(trainer.Target))[0],
+		})
+	}
+	return outcome, nil
+}
+
+// LearningCurveRows is the maximal amount of rows of the learning curve
+// printed by Outcome.Print; the episodes are averaged in groups to fit.
+const LearningCurveRows = 20
+
+// Print writes the learning curve and the learned policy.
+func (outcome *Outcome) Print(w io.Writer) {
+	trainer := outcome.Trainer
+	fmt.Fprintf(w, "training: %s\n", trainer.Base.Population[trainer.Target].Label())
+	fmt.Fprintf(w, "master seed: %d\n", trainer.Seed)
+
+	fmt.Fprintf(w, "\nlearning curve:\n")
+	groupSize := (len(outcome.Episodes) + LearningCurveRows - 1) / LearningCurveRows
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "episodes\tmean growth rate\t\n")
+	for start := 0; start < len(outcome.Episodes); start += groupSize {
+		end := min(start+groupSize, len(outcome.Episodes))
+		growthRates := make([]float64, 0, end-start)
+		for _, episode := range outcome.Episodes[start:end] {
+			growthRates = append(growthRates, episode.GrowthRate)
+		}
+		fmt.Fprintf(tw, "%d-%d\t%.2f%%\t\n", start, end-1, stats.Mean(growthRates))
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\npreferred actions (by the amount of visited observations):\n")
+	policy := trainer.Learner.Policy()
+	for action := strategy.QActionEat; action <= strategy.QActionShareWithEverybody; action++ {
+		fmt.Fprintf(w, "\t%s: %d\n", action, policy[action])
+	}
+}
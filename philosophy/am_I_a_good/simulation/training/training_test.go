@@ -0,0 +1,97 @@
+package training
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/scenario"
+	"github.com/xaionaro/scenario/philosophy/am_I_a_good/simulation/strategy"
+)
+
+func testTrainer(t *testing.T) *Trainer {
+	base, err := scenario.Parse([]byte(`
+name: train
+tries: 5
+weeks: 100
+world:
+  enable_children: false
+  enable_aging: false
+  amount_of_portions: 15
+population:
+  - strategy: eat_the_rest
+    citizens: 10
+  - strategy: q_learning
+    citizens: 10
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	learner, err := strategy.New("q_learning", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Trainer{
+		Base:     base,
+		Target:   1,
+		Learner:  learner.(*strategy.QLearner),
+		Episodes: 6,
+		Seed:     1,
+	}
+}
+
+func TestRun(t *testing.T) {
+	trainer := testTrainer(t)
+	outcome, err := trainer.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcome.Episodes) != 6 {
+		t.Fatalf("%d episodes were played instead of 6", len(outcome.Episodes))
+	}
+	for idx, episode := range outcome.Episodes {
+		if episode.Index != uint(idx) {
+			t.Errorf("episode #%d has index %d", idx, episode.Index)
+		}
+	}
+	if !trainer.Learner.Training {
+		t.Errorf("the learner left the training mode")
+	}
+	if *trainer.Learner.Table == (strategy.QTable{}) {
+		t.Fatalf("nothing was learned")
+	}
+
+	again := testTrainer(t)
+	if _, err := again.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if *again.Learner.Table != *trainer.Learner.Table {
+		t.Errorf("the same training learned different tables")
+	}
+
+	// the training continues from the learned table
+	table := *trainer.Learner.Table
+	trainer.Episodes = 1
+	if _, err := trainer.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if *trainer.Learner.Table == table {
+		t.Errorf("another episode learned nothing")
+	}
+
+	var buf bytes.Buffer
+	outcome.Print(&buf)
+	for _, expected := range []string{"training: q_learning", "master seed: 1", "0-0", "5-5", "preferred actions"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("the output does not contain %q:\n%s", expected, buf.String())
+		}
+	}
+}
+
+func TestRunInvalidTarget(t *testing.T) {
+	trainer := testTrainer(t)
+	trainer.Target = 2
+	if _, err := trainer.Run(); err == nil {
+		t.Errorf("expected an error")
+	}
+}